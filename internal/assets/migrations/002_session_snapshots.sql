@@ -0,0 +1,23 @@
+-- +migrate Up
+
+create table session_snapshots
+(
+    session_type        integer primary key not null,
+    session_id          bigint             not null,
+    controller          integer            not null,
+    begin_block         bigint             not null,
+    controller_block    bigint             not null,
+    processing          boolean            not null,
+    proposer            text,
+    indexes             text[] not null,
+    root                text,
+    acceptances         text[] not null,
+    signers             text[] not null,
+    offenders           text[] not null,
+    is_signer           boolean            not null,
+    operation_signature text,
+    key_signature       text
+);
+
+-- +migrate Down
+drop table session_snapshots;
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+alter table session_snapshots drop constraint session_snapshots_pkey;
+alter table session_snapshots add primary key (session_type, session_id);
+
+-- +migrate Down
+delete from session_snapshots s where exists (
+    select 1 from session_snapshots n where n.session_type = s.session_type and n.session_id > s.session_id
+);
+alter table session_snapshots drop constraint session_snapshots_pkey;
+alter table session_snapshots add primary key (session_type);
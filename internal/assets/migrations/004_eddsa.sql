@@ -0,0 +1,22 @@
+-- +migrate Up
+
+alter table default_session_data add column eddsa_signature text;
+
+alter table reshare_session_data add column new_eddsa_key text;
+alter table reshare_session_data add column eddsa_key_signature text;
+
+alter table keygen_session_data add column eddsa_key text;
+
+alter table session_snapshots add column eddsa_operation_signature text;
+alter table session_snapshots add column eddsa_key_signature text;
+
+-- +migrate Down
+alter table session_snapshots drop column eddsa_key_signature;
+alter table session_snapshots drop column eddsa_operation_signature;
+
+alter table keygen_session_data drop column eddsa_key;
+
+alter table reshare_session_data drop column eddsa_key_signature;
+alter table reshare_session_data drop column new_eddsa_key;
+
+alter table default_session_data drop column eddsa_signature;
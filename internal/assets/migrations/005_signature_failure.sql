@@ -0,0 +1,8 @@
+-- +migrate Up
+
+alter table default_session_data add column signature_failure text;
+alter table reshare_session_data add column signature_failure text;
+
+-- +migrate Down
+alter table reshare_session_data drop column signature_failure;
+alter table default_session_data drop column signature_failure;
@@ -0,0 +1,6 @@
+-- +migrate Up
+
+alter table keygen_session_data add column attempt integer not null default 1;
+
+-- +migrate Down
+alter table keygen_session_data drop column attempt;
@@ -0,0 +1,6 @@
+-- +migrate Up
+
+alter table session_snapshots add column culprits text;
+
+-- +migrate Down
+alter table session_snapshots drop column culprits;
@@ -2,8 +2,12 @@ package cli
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
@@ -19,6 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rarimo/tss-svc/internal/config"
+	"github.com/rarimo/tss-svc/internal/connectors"
 	"github.com/rarimo/tss-svc/internal/core"
 	"github.com/rarimo/tss-svc/internal/core/empty"
 	"github.com/rarimo/tss-svc/internal/core/keygen"
@@ -26,6 +31,7 @@ import (
 	"github.com/rarimo/tss-svc/internal/core/sign"
 	"github.com/rarimo/tss-svc/internal/grpc"
 	"github.com/rarimo/tss-svc/internal/pool"
+	"github.com/rarimo/tss-svc/internal/secret"
 	"github.com/rarimo/tss-svc/internal/timer"
 	"github.com/rarimo/tss-svc/pkg/types"
 	"gitlab.com/distributed_lab/kit/kv"
@@ -45,6 +51,9 @@ func Run(args []string) {
 	// Running full service
 	serviceCmd := runCmd.Command("service", "run service")
 
+	// Running service in observer mode (without key share, never signs or submits anything)
+	observerCmd := runCmd.Command("observer", "run observer")
+
 	// Running service in keygen mode
 	keygenCmd := runCmd.Command("keygen", "run keygen")
 
@@ -54,6 +63,9 @@ func Run(args []string) {
 	// Running ECDSA key-pair generation
 	prvgenCmd := runCmd.Command("prvgen", "run prvgen")
 
+	// Running mutual TLS key generation
+	tlsgenCmd := runCmd.Command("tlsgen", "run tlsgen")
+
 	// Running migrations
 	migrateCmd := app.Command("migrate", "migrate command")
 	migrateUpCmd := migrateCmd.Command("up", "migrate db up")
@@ -84,7 +96,8 @@ func Run(args []string) {
 		go profiling(c)
 
 		cfg := config.New(kv.MustFromEnv())
-		core.Initialize(cfg)
+		registerSessions()
+		core.Initialize(cfg, connectors.NewGRPCTransport())
 
 		ctx := core.DefaultGlobalContext(c)
 		go timer.NewBlockSubscriber(ctx.Timer(), ctx.Tendermint(), ctx.Log()).Run(ctx.Context())
@@ -98,14 +111,36 @@ func Run(args []string) {
 		go pool.NewCSCARootUpdateOperationSubscriber(ctx.Pool(), ctx.Tendermint(), ctx.Log()).Run(ctx.Context())
 		go pool.NewArbitraryOperationSubscriber(ctx.Pool(), ctx.Tendermint(), ctx.Log()).Run(ctx.Context())
 		go pool.NewOperationCatchupper(ctx.Pool(), ctx.Client(), ctx.Log()).Run(ctx.Context())
+		go secret.NewPreParamsGenerator(ctx.SecretStorage(), cfg.PreParams(), ctx.Log()).Run(ctx.Context())
+		go connectors.NewClientsCleaner(cfg.Connections(), ctx.Log()).Run(ctx.Context())
+
+		manager := newSessionManager(ctx, cfg, types.SessionType_ReshareSession, types.SessionType_DefaultSession)
+
+		ctx.Timer().SubscribeToBlocks("session-manager", manager.NewBlock)
+
+		server := grpc.NewServer(ctx.Log(), ctx.Listener(), ctx.PG(), ctx.SecretStorage(), ctx.Pool(), ctx.Swagger(), cfg.PreParams(), manager)
+		go func() {
+			if err := server.RunGateway(ctx.Context()); err != nil {
+				ctx.Log().WithError(err).Fatal("rest gateway server error")
+			}
+		}()
+
+		err = server.RunGRPC(ctx.Context())
+	case observerCmd.FullCommand():
+		go profiling(c)
+
+		cfg := config.New(kv.MustFromEnv())
+		registerSessions()
+		core.InitializeObserver(cfg, connectors.NewGRPCTransport())
+
+		ctx := core.DefaultGlobalContext(c)
+		go timer.NewBlockSubscriber(ctx.Timer(), ctx.Tendermint(), ctx.Log()).Run(ctx.Context())
 
-		manager := core.NewSessionManager()
-		manager.AddSession(types.SessionType_ReshareSession, empty.NewEmptySession(ctx, cfg.Session(), types.SessionType_ReshareSession, reshare.NewSession))
-		manager.AddSession(types.SessionType_DefaultSession, empty.NewEmptySession(ctx, cfg.Session(), types.SessionType_DefaultSession, sign.NewSession))
+		manager := newSessionManager(ctx, cfg, types.SessionType_ReshareSession, types.SessionType_DefaultSession)
 
 		ctx.Timer().SubscribeToBlocks("session-manager", manager.NewBlock)
 
-		server := grpc.NewServer(ctx.Log(), ctx.Listener(), ctx.PG(), ctx.SecretStorage(), ctx.Pool(), ctx.Swagger(), manager)
+		server := grpc.NewServer(ctx.Log(), ctx.Listener(), ctx.PG(), ctx.SecretStorage(), ctx.Pool(), ctx.Swagger(), nil, manager)
 		go func() {
 			if err := server.RunGateway(ctx.Context()); err != nil {
 				ctx.Log().WithError(err).Fatal("rest gateway server error")
@@ -117,18 +152,20 @@ func Run(args []string) {
 		go profiling(c)
 
 		cfg := config.New(kv.MustFromEnv())
-		core.Initialize(cfg)
+		registerSessions()
+		core.Initialize(cfg, connectors.NewGRPCTransport())
 
 		ctx := core.DefaultGlobalContext(c)
 
 		go timer.NewBlockSubscriber(ctx.Timer(), ctx.Tendermint(), ctx.Log()).Run(ctx.Context())
+		go secret.NewPreParamsGenerator(ctx.SecretStorage(), cfg.PreParams(), ctx.Log()).Run(ctx.Context())
+		go connectors.NewClientsCleaner(cfg.Connections(), ctx.Log()).Run(ctx.Context())
 
-		manager := core.NewSessionManager()
-		manager.AddSession(types.SessionType_KeygenSession, empty.NewEmptySession(ctx, cfg.Session(), types.SessionType_KeygenSession, keygen.NewSession))
+		manager := newSessionManager(ctx, cfg, types.SessionType_KeygenSession)
 
 		ctx.Timer().SubscribeToBlocks("session-manager", manager.NewBlock)
 
-		server := grpc.NewServer(ctx.Log(), ctx.Listener(), ctx.PG(), ctx.SecretStorage(), ctx.Pool(), ctx.Swagger(), manager)
+		server := grpc.NewServer(ctx.Log(), ctx.Listener(), ctx.PG(), ctx.SecretStorage(), ctx.Pool(), ctx.Swagger(), cfg.PreParams(), manager)
 		go func() {
 			if err := server.RunGateway(ctx.Context()); err != nil {
 				ctx.Log().WithError(err).Fatal("rest gateway server error")
@@ -156,6 +193,18 @@ func Run(args []string) {
 		keypair, _ := crypto.GenerateKey()
 		fmt.Println("Pub: " + hexutil.Encode(elliptic.Marshal(secp256k1.S256(), keypair.X, keypair.Y)))
 		fmt.Println("Prv: " + hexutil.Encode(keypair.D.Bytes()))
+	case tlsgenCmd.FullCommand():
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Print(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
 	case migrateUpCmd.FullCommand():
 		cfg := config.New(kv.MustFromEnv())
 		err = MigrateUp(cfg)
@@ -171,6 +220,26 @@ func Run(args []string) {
 	}
 }
 
+// registerSessions registers all supported session types. Add the custom session types definitions here.
+func registerSessions() {
+	core.RegisterSession(sign.Definition())
+	core.RegisterSession(reshare.Definition())
+	core.RegisterSession(keygen.Definition())
+}
+
+// newSessionManager creates the session manager that launches the provided registered session types
+// starting from the restored sessions or empty session.
+func newSessionManager(ctx core.Context, cfg config.Config, sessionTypes ...types.SessionType) *core.SessionManager {
+	manager := core.NewSessionManager(ctx, cfg.Session().ReplayWindow)
+	for _, sessionType := range sessionTypes {
+		for _, session := range empty.NewStartSessions(ctx, cfg.Session(), core.MustGetSessionDefinition(sessionType)) {
+			manager.AddSession(sessionType, session)
+		}
+	}
+
+	return manager
+}
+
 func profiling(ctx context.Context) {
 	r := http.NewServeMux()
 	r.HandleFunc("/debug/pprof/", pprof.Index)
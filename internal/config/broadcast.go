@@ -0,0 +1,46 @@
+package config
+
+import (
+	"time"
+
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+const (
+	DefaultBroadcastPartyTimeout  = 5 * time.Second
+	DefaultBroadcastMaxConcurrent = 8
+)
+
+// BroadcastInfo defines the requests fan-out to other parties. Requests are submitted to at most MaxConcurrent
+// parties at the same time and every submission is limited by PartyTimeout, so slow parties do not delay others.
+// Observers contains the gRPC addresses of the observer services that receive the session requests shared
+// between parties. Observers are not a part of the core set, so the failed deliveries are not reported.
+type BroadcastInfo struct {
+	PartyTimeout  time.Duration `fig:"party_timeout"`
+	MaxConcurrent int           `fig:"max_concurrent"`
+	Observers     []string      `fig:"observers"`
+}
+
+func (c *config) Broadcast() *BroadcastInfo {
+	return c.broadcast.Do(func() interface{} {
+		info := &BroadcastInfo{
+			PartyTimeout:  DefaultBroadcastPartyTimeout,
+			MaxConcurrent: DefaultBroadcastMaxConcurrent,
+		}
+
+		if err := figure.Out(info).From(kv.MustGetStringMap(c.getter, "broadcast")).Please(); err != nil {
+			panic(err)
+		}
+
+		if info.PartyTimeout <= 0 {
+			info.PartyTimeout = DefaultBroadcastPartyTimeout
+		}
+
+		if info.MaxConcurrent <= 0 {
+			info.MaxConcurrent = DefaultBroadcastMaxConcurrent
+		}
+
+		return info
+	}).(*BroadcastInfo)
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"time"
+
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/kv"
+)
+
+const (
+	DefaultConnectionsIdleTimeout      = 10 * time.Minute
+	DefaultConnectionsCheckInterval    = time.Minute
+	DefaultConnectionsKeepaliveTime    = 30 * time.Second
+	DefaultConnectionsKeepaliveTimeout = 10 * time.Second
+	DefaultConnectionsMaxBackoff       = 30 * time.Second
+	// MinKeepaliveTime is the minimal keepalive ping interval permitted by the party servers
+	MinKeepaliveTime = 10 * time.Second
+)
+
+// ConnectionsInfo defines the lifecycle of the connections to other parties. Connections that have not been used
+// for IdleTimeout are closed. Broken connections are re-established with exponential backoff up to MaxBackoff.
+// Keepalive pings are sent every KeepaliveTime to detect dead connections.
+type ConnectionsInfo struct {
+	IdleTimeout      time.Duration `fig:"idle_timeout"`
+	CheckInterval    time.Duration `fig:"check_interval"`
+	KeepaliveTime    time.Duration `fig:"keepalive_time"`
+	KeepaliveTimeout time.Duration `fig:"keepalive_timeout"`
+	MaxBackoff       time.Duration `fig:"max_backoff"`
+}
+
+// DefaultConnectionsInfo returns the connections configuration used if it is not configured.
+func DefaultConnectionsInfo() *ConnectionsInfo {
+	return &ConnectionsInfo{
+		IdleTimeout:      DefaultConnectionsIdleTimeout,
+		CheckInterval:    DefaultConnectionsCheckInterval,
+		KeepaliveTime:    DefaultConnectionsKeepaliveTime,
+		KeepaliveTimeout: DefaultConnectionsKeepaliveTimeout,
+		MaxBackoff:       DefaultConnectionsMaxBackoff,
+	}
+}
+
+func (c *config) Connections() *ConnectionsInfo {
+	return c.connections.Do(func() interface{} {
+		info := DefaultConnectionsInfo()
+
+		if err := figure.Out(info).From(kv.MustGetStringMap(c.getter, "connections")).Please(); err != nil {
+			panic(err)
+		}
+
+		if info.CheckInterval <= 0 {
+			info.CheckInterval = DefaultConnectionsCheckInterval
+		}
+
+		if info.KeepaliveTime < MinKeepaliveTime {
+			info.KeepaliveTime = MinKeepaliveTime
+		}
+
+		return info
+	}).(*ConnectionsInfo)
+}
@@ -22,6 +22,9 @@ type Config interface {
 	Vault() *vault.KVv2
 	Swagger() *SwaggerInfo
 	ChainParams() *ChainParams
+	PreParams() *PreParamsInfo
+	Broadcast() *BroadcastInfo
+	Connections() *ConnectionsInfo
 }
 
 type config struct {
@@ -29,14 +32,17 @@ type config struct {
 	comfig.Listenerer
 	pgdb.Databaser
 
-	tendermint comfig.Once
-	cosmos     comfig.Once
-	storage    comfig.Once
-	session    comfig.Once
-	private    comfig.Once
-	vault      comfig.Once
-	swagger    comfig.Once
-	chain      comfig.Once
+	tendermint  comfig.Once
+	cosmos      comfig.Once
+	storage     comfig.Once
+	session     comfig.Once
+	private     comfig.Once
+	vault       comfig.Once
+	swagger     comfig.Once
+	chain       comfig.Once
+	preParams   comfig.Once
+	broadcast   comfig.Once
+	connections comfig.Once
 
 	getter kv.Getter
 }
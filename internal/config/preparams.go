@@ -0,0 +1,48 @@
+package config
+
+import (
+	"time"
+
+	"gitlab.com/distributed_lab/figure"
+	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+const (
+	DefaultPreParamsPoolSize      = 2
+	DefaultPreParamsTimeout       = 10 * time.Minute
+	DefaultPreParamsCheckInterval = time.Minute
+)
+
+// PreParamsInfo defines the background generation of the tss pre-params pool.
+// The pool keeps PoolSize validated pre-params sets that are consumed by keygen and reshare sessions.
+type PreParamsInfo struct {
+	PoolSize      int           `fig:"pool_size"`
+	Timeout       time.Duration `fig:"timeout"`
+	CheckInterval time.Duration `fig:"check_interval"`
+}
+
+func (c *config) PreParams() *PreParamsInfo {
+	return c.preParams.Do(func() interface{} {
+		info := &PreParamsInfo{
+			PoolSize:      DefaultPreParamsPoolSize,
+			Timeout:       DefaultPreParamsTimeout,
+			CheckInterval: DefaultPreParamsCheckInterval,
+		}
+
+		if err := figure.Out(info).From(kv.MustGetStringMap(c.getter, "pre_params")).Please(); err != nil {
+			panic(err)
+		}
+
+		// Keygen and reshare sessions never reuse pre-params, so the pool can not be disabled
+		if info.PoolSize <= 0 {
+			panic(errors.New("pre-params pool size should be positive"))
+		}
+
+		if info.CheckInterval <= 0 {
+			info.CheckInterval = DefaultPreParamsCheckInterval
+		}
+
+		return info
+	}).(*PreParamsInfo)
+}
@@ -1,19 +1,108 @@
 package config
 
 import (
+	"reflect"
+
+	"github.com/spf13/cast"
 	"gitlab.com/distributed_lab/figure"
 	"gitlab.com/distributed_lab/kit/kv"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 )
 
+// DefaultReplayWindowBlocks is used if the replay window is not configured.
+const DefaultReplayWindowBlocks = 10
+
 type SessionInfo struct {
-	StartBlock     uint64 `fig:"start_block"`
-	StartSessionId uint64 `fig:"start_session_id"`
+	StartBlock     uint64            `fig:"start_block"`
+	StartSessionId uint64            `fig:"start_session_id"`
+	Timeline       TimelineInfo      `fig:"timeline"`
+	Upgrades       []TimelineUpgrade `fig:"upgrades"`
+	// ReplayWindow defines the maximum difference in blocks between the request height and the current block
+	ReplayWindow uint64 `fig:"replay_window"`
+}
+
+// TimelineInfo defines the custom sessions timelines by session type name (default, keygen, reshare, etc.).
+// Session types that are not configured will use the default timeline.
+type TimelineInfo map[string]SessionTimeline
+
+// SessionTimeline defines the session and its controllers durations in blocks.
+// Zero session duration means that the timeline is not configured.
+// Interval defines the amount of blocks between the starts of consecutive sessions. If interval is less than
+// duration + 1 sessions are pipelined: the next session starts while the previous one is still running.
+// Zero interval means that sessions follow each other (duration + 1).
+type SessionTimeline struct {
+	Duration   uint64 `fig:"duration"`
+	Interval   uint64 `fig:"interval"`
+	Proposal   uint64 `fig:"proposal"`
+	Acceptance uint64 `fig:"acceptance"`
+	Keygen     uint64 `fig:"keygen"`
+	Sign       uint64 `fig:"sign"`
+}
+
+// TimelineUpgrade defines the new timelines that will be activated starting from the first session that begins
+// at or after the provided height. Session types that are not configured keep their previous timeline.
+type TimelineUpgrade struct {
+	Height   uint64       `fig:"height,required"`
+	Timeline TimelineInfo `fig:"timeline,required"`
+}
+
+var timelineHooks = figure.Hooks{
+	"config.TimelineInfo": func(value interface{}) (reflect.Value, error) {
+		values, err := cast.ToStringMapE(value)
+		if err != nil {
+			return reflect.Value{}, errors.Wrap(err, "failed to parse timelines")
+		}
+
+		info := make(TimelineInfo, len(values))
+		for name, raw := range values {
+			timelineValues, err := cast.ToStringMapE(raw)
+			if err != nil {
+				return reflect.Value{}, errors.Wrap(err, "failed to parse timeline", logan.F{"type": name})
+			}
+
+			var timeline SessionTimeline
+			if err := figure.Out(&timeline).From(timelineValues).Please(); err != nil {
+				return reflect.Value{}, errors.Wrap(err, "failed to figure out timeline", logan.F{"type": name})
+			}
+
+			info[name] = timeline
+		}
+
+		return reflect.ValueOf(info), nil
+	},
+}
+
+var sessionHooks = figure.Hooks{
+	"[]config.TimelineUpgrade": func(value interface{}) (reflect.Value, error) {
+		list, err := cast.ToSliceE(value)
+		if err != nil {
+			return reflect.Value{}, errors.Wrap(err, "failed to parse timeline upgrades list")
+		}
+
+		upgrades := make([]TimelineUpgrade, 0, len(list))
+		for i, raw := range list {
+			values, err := cast.ToStringMapE(raw)
+			if err != nil {
+				return reflect.Value{}, errors.Wrap(err, "failed to parse timeline upgrade", logan.F{"index": i})
+			}
+
+			var upgrade TimelineUpgrade
+			if err := figure.Out(&upgrade).With(figure.BaseHooks, timelineHooks).From(values).Please(); err != nil {
+				return reflect.Value{}, errors.Wrap(err, "failed to figure out timeline upgrade", logan.F{"index": i})
+			}
+
+			upgrades = append(upgrades, upgrade)
+		}
+
+		return reflect.ValueOf(upgrades), nil
+	},
 }
 
 func (c *config) Session() *SessionInfo {
 	return c.session.Do(func() interface{} {
-		info := &SessionInfo{}
-		if err := figure.Out(info).From(kv.MustGetStringMap(c.getter, "session")).Please(); err != nil {
+		info := &SessionInfo{ReplayWindow: DefaultReplayWindowBlocks}
+		if err := figure.Out(info).With(figure.BaseHooks, timelineHooks, sessionHooks).From(kv.MustGetStringMap(c.getter, "session")).Please(); err != nil {
 			panic(err)
 		}
 		return info
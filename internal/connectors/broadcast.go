@@ -4,48 +4,119 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/config"
 	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/internal/timer"
 	"github.com/rarimo/tss-svc/pkg/types"
 	"gitlab.com/distributed_lab/logan/v3"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
+// sequence is shared by all connectors of the party. It starts from the current time to stay increasing after restarts.
+var sequence = func() *atomic.Uint64 {
+	seq := new(atomic.Uint64)
+	seq.Store(uint64(time.Now().UnixNano()))
+	return seq
+}()
+
+// observerRequests defines the request types that are forwarded to the observers (see config.BroadcastInfo).
+// Observers follow the sessions using the proposals, acceptances and ready signals only.
+var observerRequests = map[types.RequestType]struct{}{
+	types.RequestType_Proposal:   {},
+	types.RequestType_Acceptance: {},
+	types.RequestType_Ready:      {},
+}
+
 // BroadcastConnector uses SubmitConnector to broadcast request to all parties, except of self.
+// Request is submitted to several parties concurrently and every submission is limited by the party timeout.
 // Is request submission fails, there will be ONE retry after last party submission.
 type BroadcastConnector struct {
 	*SubmitConnector
 	sessionType types.SessionType
 	parties     []*rarimo.Party
+	observers   []*rarimo.Party
 	sc          *secret.TssSecret
+	timer       *timer.Timer
+	info        *config.BroadcastInfo
 	log         *logan.Entry
+	pending     atomic.Int64
 }
 
-func NewBroadcastConnector(sessionType types.SessionType, parties []*rarimo.Party, sc *secret.TssSecret, log *logan.Entry) *BroadcastConnector {
+func NewBroadcastConnector(sessionType types.SessionType, parties []*rarimo.Party, sc *secret.TssSecret, transport Transport, timer *timer.Timer, info *config.BroadcastInfo, log *logan.Entry) *BroadcastConnector {
+	observers := make([]*rarimo.Party, 0, len(info.Observers))
+	for _, addr := range info.Observers {
+		observers = append(observers, &rarimo.Party{Address: addr})
+	}
+
 	return &BroadcastConnector{
-		SubmitConnector: NewSubmitConnector(sc),
+		SubmitConnector: NewSubmitConnector(sc, transport),
 		sessionType:     sessionType,
 		parties:         parties,
+		observers:       observers,
 		sc:              sc,
+		timer:           timer,
+		info:            info,
 		log:             log,
 	}
 }
 
 func (b *BroadcastConnector) SubmitAllWithReport(ctx context.Context, coreCon *CoreConnector, request *types.MsgSubmitRequest) {
 	retry := b.SubmitToWithReport(ctx, coreCon, request, b.parties...)
+	b.submitToObservers(ctx, request)
 	b.SubmitToWithReport(ctx, coreCon, request, retry...)
 }
 
+// submitToObservers forwards the signed request to the configured observers. Failed deliveries are only logged.
+func (b *BroadcastConnector) submitToObservers(ctx context.Context, request *types.MsgSubmitRequest) {
+	if _, ok := observerRequests[request.Data.Type]; !ok || request.Signature == "" {
+		return
+	}
+
+	b.fanOut(b.observers, func(observer *rarimo.Party) {
+		observerCtx, cancel := context.WithTimeout(ctx, b.info.PartyTimeout)
+		defer cancel()
+
+		if _, err := b.submitSigned(observerCtx, observer, request); err != nil {
+			b.log.WithError(err).Debugf("Error submitting request to observer: %s", observer.Address)
+		}
+	})
+}
+
+// SubmitAllWithReportAsync launches SubmitAllWithReport in separate goroutine.
+// Use Pending to check if the submission has been finished.
+func (b *BroadcastConnector) SubmitAllWithReportAsync(ctx context.Context, coreCon *CoreConnector, request *types.MsgSubmitRequest) {
+	b.pending.Add(1)
+	go func() {
+		defer b.pending.Add(-1)
+		b.SubmitAllWithReport(ctx, coreCon, request)
+	}()
+}
+
+// Pending returns true if there are asynchronous submissions that have not been finished yet.
+func (b *BroadcastConnector) Pending() bool {
+	return b.pending.Load() > 0
+}
+
 // Deprecated: SubmitAll is deprecated. Use SubmitAllWithReport instead
 func (b *BroadcastConnector) SubmitAll(ctx context.Context, request *types.MsgSubmitRequest) {
 	retry := b.SubmitTo(ctx, request, b.parties...)
 	b.SubmitTo(ctx, request, retry...)
 }
 
+// SubmitToWithReport submits the request to the provided parties (except of self) and returns the parties that have
+// rejected the request, so it can be resubmitted later. Offline parties are reported to the core.
 func (b *BroadcastConnector) SubmitToWithReport(ctx context.Context, coreCon *CoreConnector, request *types.MsgSubmitRequest, parties ...*rarimo.Party) []*rarimo.Party {
 	request.Data.SessionType = b.sessionType
+	b.stamp(request)
+
+	// Request is signed once to be submitted concurrently
+	if err := b.sc.Sign(request); err != nil {
+		b.log.WithError(err).Error("Error signing request")
+		return nil
+	}
 
 	failed := struct {
 		mu  sync.Mutex
@@ -54,44 +125,91 @@ func (b *BroadcastConnector) SubmitToWithReport(ctx context.Context, coreCon *Co
 		arr: make([]*rarimo.Party, 0, len(b.parties)),
 	}
 
-	for _, party := range parties {
-		if party.Account != b.sc.AccountAddress() {
-			b.log.Debugf("Sending message to: %s, addr: %s", party.Account, party.Address)
-			if _, err := b.Submit(ctx, party, request); err != nil {
-				b.log.WithError(err).Errorf("Error submitting request to party: %s addr: %s", party.Account, party.Address)
+	b.fanOut(parties, func(party *rarimo.Party) {
+		if b.submitWithReport(ctx, coreCon, request, party) {
+			return
+		}
 
-				// check that party returned an error
-				if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
-					func() {
-						failed.mu.Lock()
-						defer failed.mu.Unlock()
-						failed.arr = append(failed.arr, party)
-					}()
-					continue
-				}
+		failed.mu.Lock()
+		defer failed.mu.Unlock()
+		failed.arr = append(failed.arr, party)
+	})
 
-				if err := coreCon.SubmitReport(
-					request.Data.Id,
-					rarimo.ViolationType_Offline,
-					party.Account,
-					fmt.Sprintf("Party was offline when tried to submit %s request", request.Data.Type),
-				); err != nil {
-					b.log.WithError(err).Errorf("Error submitting violation report for party: %s", party.Account)
-				}
+	return failed.arr
+}
+
+// submitWithReport submits the signed request to the party with the party timeout. Returns false if the party has
+// rejected the request. The party is reported as offline if the request has not been delivered.
+func (b *BroadcastConnector) submitWithReport(ctx context.Context, coreCon *CoreConnector, request *types.MsgSubmitRequest, party *rarimo.Party) bool {
+	b.log.Debugf("Sending message to: %s, addr: %s", party.Account, party.Address)
+
+	partyCtx, cancel := context.WithTimeout(ctx, b.info.PartyTimeout)
+	defer cancel()
+
+	if _, err := b.submitSigned(partyCtx, party, request); err != nil {
+		b.log.WithError(err).Errorf("Error submitting request to party: %s addr: %s", party.Account, party.Address)
 
-				continue
-			}
+		// check that party returned an error
+		if rejected(err) {
+			return false
+		}
 
-			b.log.Debugf("Successfully sent message to: %s, addr: %s", party.Account, party.Address)
+		if err := coreCon.SubmitReport(
+			request.Data.Id,
+			rarimo.ViolationType_Offline,
+			party.Account,
+			fmt.Sprintf("Party was offline when tried to submit %s request", request.Data.Type),
+		); err != nil {
+			b.log.WithError(err).Errorf("Error submitting violation report for party: %s", party.Account)
 		}
+
+		return true
 	}
 
-	return failed.arr
+	b.log.Debugf("Successfully sent message to: %s, addr: %s", party.Account, party.Address)
+	return true
+}
+
+// fanOut calls f for every party except of self running at most MaxConcurrent calls at the same time.
+// Returns after all calls have been finished.
+func (b *BroadcastConnector) fanOut(parties []*rarimo.Party, f func(party *rarimo.Party)) {
+	sem := make(chan struct{}, b.info.MaxConcurrent)
+	wg := &sync.WaitGroup{}
+
+	for _, party := range parties {
+		if party.Account == b.sc.AccountAddress() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(party *rarimo.Party) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			f(party)
+		}(party)
+	}
+
+	wg.Wait()
+}
+
+// stamp sets the sequence and height for the new request. The request that has already been stamped keeps its values,
+// so the same request sent to several parties or resubmitted on retry can be recognized by the receiver as a duplicate.
+func (b *BroadcastConnector) stamp(request *types.MsgSubmitRequest) {
+	if request.Data.Sequence != 0 {
+		return
+	}
+
+	request.Data.Sequence = sequence.Add(1)
+	request.Data.Height = b.timer.CurrentBlock()
 }
 
 // Deprecated: SubmitTo is deprecated. Use SubmitToWithReport instead
 func (b *BroadcastConnector) SubmitTo(ctx context.Context, request *types.MsgSubmitRequest, parties ...*rarimo.Party) []*rarimo.Party {
 	request.Data.SessionType = b.sessionType
+	b.stamp(request)
 
 	failed := struct {
 		mu  sync.Mutex
@@ -0,0 +1,266 @@
+package connectors
+
+import (
+	"context"
+	"crypto/tls"
+	goerr "errors"
+	"sync"
+	"time"
+
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/config"
+	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+type con struct {
+	client   *grpc.ClientConn
+	account  string
+	address  string
+	pubKey   string
+	lastUsed time.Time
+
+	mu     sync.Mutex
+	stream *partyStream
+	unary  bool
+}
+
+// submit sends the signed request over the party stream. Falls back to the unary Submit if the party does not
+// support streaming or the connection is not ready to open the stream yet.
+func (c *con) submit(ctx context.Context, request *types.MsgSubmitRequest) error {
+	if stream := c.getStream(); stream != nil {
+		err := stream.submit(ctx, request)
+		switch {
+		case unimplemented(err):
+			c.fallback()
+		case goerr.Is(err, ErrStreamClosed):
+			// request has not been acknowledged, it is resubmitted as unary request and the next request
+			// will open the new stream
+		default:
+			return err
+		}
+	}
+
+	_, err := types.NewServiceClient(c.client).Submit(ctx, request)
+	return err
+}
+
+// getStream returns the opened stream or opens the new one if the connection is ready.
+// Returns nil if the unary requests should be used.
+func (c *con) getStream() *partyStream {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.unary {
+		return nil
+	}
+
+	if c.stream != nil && !c.stream.closed() {
+		return c.stream
+	}
+
+	// Opening the stream waits for the connection, so the unary request limited by the caller context is used instead
+	if c.client.GetState() != connectivity.Ready {
+		return nil
+	}
+
+	stream, err := openStream(c.client)
+	if err != nil {
+		return nil
+	}
+
+	c.stream = stream
+	return stream
+}
+
+// fallback switches the connection to unary requests. The party is checked for streaming support again when
+// the connection is replaced after eviction or party address change.
+func (c *con) fallback() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unary = true
+}
+
+func (c *con) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stream != nil {
+		c.stream.close()
+	}
+
+	_ = c.client.Close()
+}
+
+// clientsPool holds the connections to other parties to reduce submitting time.
+type clientsPool struct {
+	mu      sync.Mutex
+	info    *config.ConnectionsInfo
+	clients map[string]*con
+}
+
+var clientsBuffer = &clientsPool{
+	info:    config.DefaultConnectionsInfo(),
+	clients: make(map[string]*con),
+}
+
+// get returns the buffered connection to the party. With mutual TLS the connection authenticates both
+// parties by their tss keys, so it is buffered per party and self tss keys that can be changed by resharing.
+// Shut down connections are replaced and idle connections are woken up to reconnect.
+func (p *clientsPool) get(party *rarimo.Party, secret *secret.TssSecret) (*con, error) {
+	key := party.Address
+	if mutualTLS(party, secret) {
+		key = party.Address + "/" + party.PubKey + "/" + secret.TssPubKey()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[key]; ok {
+		switch c.client.GetState() {
+		case connectivity.Shutdown:
+			delete(p.clients, key)
+		case connectivity.Idle:
+			c.client.Connect()
+			fallthrough
+		default:
+			c.lastUsed = time.Now().UTC()
+			return c, nil
+		}
+	}
+
+	client, err := p.dial(party, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &con{
+		client:   client,
+		account:  party.Account,
+		address:  party.Address,
+		pubKey:   party.PubKey,
+		lastUsed: time.Now().UTC(),
+	}
+
+	p.clients[key] = c
+	return c, nil
+}
+
+func (p *clientsPool) dial(party *rarimo.Party, secret *secret.TssSecret) (*grpc.ClientConn, error) {
+	connectSecurityOptions := grpc.WithInsecure()
+
+	switch {
+	case mutualTLS(party, secret):
+		connectSecurityOptions = grpc.WithTransportCredentials(credentials.NewTLS(secret.ClientTLSConfig(party.PubKey)))
+	case secret.TLS():
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS13,
+		}
+
+		connectSecurityOptions = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	backoffConfig := backoff.DefaultConfig
+	backoffConfig.MaxDelay = p.info.MaxBackoff
+
+	return grpc.Dial(party.Address,
+		connectSecurityOptions,
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                p.info.KeepaliveTime,    // wait time before ping if no activity
+			Timeout:             p.info.KeepaliveTimeout, // ping timeout
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoffConfig,
+			MinConnectTimeout: p.info.KeepaliveTimeout,
+		}),
+	)
+}
+
+// mutualTLS checks if the connection to the party should be authenticated with mutual TLS.
+// Observers do not hold tss keys, so the connections to them use plain TLS settings.
+func mutualTLS(party *rarimo.Party, secret *secret.TssSecret) bool {
+	return secret.MutualTLS() && party.PubKey != ""
+}
+
+// evict closes the connections that have not been used for the idle timeout and the connections that have been shut down.
+func (p *clientsPool) evict(log *logan.Entry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	deadline := time.Now().UTC().Add(-p.info.IdleTimeout)
+	for key, c := range p.clients {
+		if c.lastUsed.Before(deadline) || c.client.GetState() == connectivity.Shutdown {
+			log.Debugf("Closing connection to %s", c.address)
+			p.close(key, c)
+		}
+	}
+}
+
+// invalidate closes the connections to the parties that have changed their address or key or have been removed.
+func (p *clientsPool) invalidate(parties []*rarimo.Party) {
+	actual := make(map[string]*rarimo.Party, len(parties))
+	for _, party := range parties {
+		actual[party.Account] = party
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, c := range p.clients {
+		if party, ok := actual[c.account]; !ok || party.Address != c.address || party.PubKey != c.pubKey {
+			p.close(key, c)
+		}
+	}
+}
+
+// close removes the connection from the buffer. Pending calls on the closed connection will fail and be reported
+// as usual, closing error is ignored because the connection is never used again.
+func (p *clientsPool) close(key string, c *con) {
+	delete(p.clients, key)
+	c.close()
+}
+
+// InvalidateClients closes the buffered connections that do not match the actual parties set.
+func InvalidateClients(parties []*rarimo.Party) {
+	clientsBuffer.invalidate(parties)
+}
+
+// ClientsCleaner periodically closes idle and broken connections to other parties.
+type ClientsCleaner struct {
+	info *config.ConnectionsInfo
+	log  *logan.Entry
+}
+
+// NewClientsCleaner configures the connections buffer and creates the cleaner for it.
+func NewClientsCleaner(info *config.ConnectionsInfo, log *logan.Entry) *ClientsCleaner {
+	clientsBuffer.mu.Lock()
+	defer clientsBuffer.mu.Unlock()
+	clientsBuffer.info = info
+
+	return &ClientsCleaner{
+		info: info,
+		log:  log,
+	}
+}
+
+func (c *ClientsCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.info.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info("Context finished")
+			return
+		case <-ticker.C:
+			clientsBuffer.evict(c.log)
+		}
+	}
+}
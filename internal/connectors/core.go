@@ -2,6 +2,7 @@ package connectors
 
 import (
 	"context"
+	goerr "errors"
 	"fmt"
 
 	sdkclient "github.com/cosmos/cosmos-sdk/client"
@@ -26,6 +27,8 @@ const (
 	successTxCode = 0
 )
 
+var ErrReadOnlyConnector = goerr.New("transactions submitting is disabled for read-only connector")
+
 // CoreConnector submits signed confirmations to the rarimo core
 type CoreConnector struct {
 	txclient        client.ServiceClient
@@ -35,6 +38,7 @@ type CoreConnector struct {
 	chainId         string
 	coin            string
 	reportsDisabled bool
+	readOnly        bool
 	log             *logan.Entry
 }
 
@@ -51,6 +55,14 @@ func NewCoreConnector(cli *grpc.ClientConn, secret *secret.TssSecret, log *logan
 	}
 }
 
+// NewReadOnlyCoreConnector creates the connector that never submits transactions to the rarimo core.
+// Used by the observer service that does not hold the account keys.
+func NewReadOnlyCoreConnector(cli *grpc.ClientConn, secret *secret.TssSecret, log *logan.Entry, params *config.ChainParams) *CoreConnector {
+	connector := NewCoreConnector(cli, secret, log, params)
+	connector.readOnly = true
+	return connector
+}
+
 func (c *CoreConnector) SubmitChangeSet(set []*rarimo.Party, sig string) error {
 	msg := &rarimo.MsgCreateChangePartiesOp{
 		Creator:   c.secret.AccountAddress(),
@@ -94,6 +106,11 @@ func (c *CoreConnector) SubmitReport(sessionId uint64, typ rarimo.ViolationType,
 }
 
 func (c *CoreConnector) Submit(msgs ...sdk.Msg) error {
+	if c.readOnly {
+		c.log.Info("Read-only connector - skipping.")
+		return ErrReadOnlyConnector
+	}
+
 	tx, err := c.build(0, 0, msgs...)
 	if err != nil {
 		return err
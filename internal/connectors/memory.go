@@ -0,0 +1,64 @@
+package connectors
+
+import (
+	"context"
+	"sync"
+
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// InMemoryTransport delivers requests to the receivers registered in the same process by the party address.
+// Allows running several parties inside one binary with the same errors semantics as GRPCTransport has.
+type InMemoryTransport struct {
+	mu        sync.RWMutex
+	receivers map[string]Receiver
+}
+
+func NewInMemoryTransport() *InMemoryTransport {
+	return &InMemoryTransport{
+		receivers: make(map[string]Receiver),
+	}
+}
+
+var _ Transport = &InMemoryTransport{}
+
+// Register sets the receiver of the requests submitted to the party with provided address.
+func (m *InMemoryTransport) Register(address string, receiver Receiver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.receivers[address] = receiver
+}
+
+// Unregister removes the receiver, so the party becomes unreachable.
+func (m *InMemoryTransport) Unregister(address string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.receivers, address)
+}
+
+func (m *InMemoryTransport) Submit(ctx context.Context, _ *secret.TssSecret, party *rarimo.Party, request *types.MsgSubmitRequest) error {
+	m.mu.RLock()
+	receiver, ok := m.receivers[party.Address]
+	m.mu.RUnlock()
+
+	if !ok {
+		return status.Errorf(codes.Unavailable, "party %s is not reachable", party.Address)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	// Request is shared between all parties the sender broadcasts it to, so every receiver gets its own copy
+	// in the same way as it would be decoded from the wire.
+	if err := receiver.Receive(ctx, proto.Clone(request).(*types.MsgSubmitRequest)); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package connectors
+
+import (
+	"context"
+	goerr "errors"
+	"io"
+	"sync"
+
+	"github.com/rarimo/tss-svc/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrStreamClosed is returned if the stream has been closed before the request was acknowledged, so the request can be
+// safely submitted in another way: the request that has been already received by the party is acknowledged again
+// without processing (see core.ReplayGuard).
+var ErrStreamClosed = goerr.New("party stream has been closed")
+
+// partyStream is the long-lived bidirectional stream to the party. Requests are sent as frames in order of
+// submitting and every frame is acknowledged by the party with the result of its processing.
+type partyStream struct {
+	stream types.Service_StreamClient
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	seq     uint64
+	pending map[uint64]chan *types.StreamAck
+	err     error
+}
+
+func openStream(client *grpc.ClientConn) (*partyStream, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := types.NewServiceClient(client).Stream(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s := &partyStream{
+		stream:  stream,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		pending: make(map[uint64]chan *types.StreamAck),
+	}
+
+	go s.receive()
+	return s, nil
+}
+
+// submit sends the signed request and waits for the acknowledgement. Rejected request error has the same status
+// code as the Submit error would have.
+func (s *partyStream) submit(ctx context.Context, request *types.MsgSubmitRequest) error {
+	ack := make(chan *types.StreamAck, 1)
+
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return ErrStreamClosed
+	}
+
+	s.seq++
+	seq := s.seq
+	s.pending[seq] = ack
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.pending, seq)
+	}()
+
+	if err := s.send(&types.StreamFrame{Seq: seq, Request: request}); err != nil {
+		// The actual stream error (instead of io.EOF returned by Send) will be received by the receiving routine
+		select {
+		case <-s.done:
+			return s.err
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	select {
+	case res := <-ack:
+		if res.Code != uint32(codes.OK) {
+			return status.Error(codes.Code(res.Code), res.Message)
+		}
+
+		return nil
+	case <-s.done:
+		return s.err
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (s *partyStream) send(frame *types.StreamFrame) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.stream.Send(frame)
+}
+
+func (s *partyStream) receive() {
+	for {
+		res, err := s.stream.Recv()
+		// Stream has been closed by the party normally (for example, on the server restart),
+		// so the party is not considered offline and the request is resubmitted
+		if err == io.EOF {
+			s.closeWithError(ErrStreamClosed)
+			return
+		}
+
+		if err != nil {
+			s.closeWithError(err)
+			return
+		}
+
+		s.mu.Lock()
+		if ack, ok := s.pending[res.Seq]; ok {
+			ack <- res
+		}
+		s.mu.Unlock()
+	}
+}
+
+// closed returns true if the stream can not be used for the new requests.
+func (s *partyStream) closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err != nil
+}
+
+func (s *partyStream) close() {
+	s.closeWithError(ErrStreamClosed)
+}
+
+func (s *partyStream) closeWithError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return
+	}
+
+	s.err = err
+	s.cancel()
+	close(s.done)
+}
+
+// unimplemented returns true if the party does not support streaming, so the request has not been processed.
+func unimplemented(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.Unimplemented
+}
@@ -0,0 +1,51 @@
+package connectors
+
+import (
+	"context"
+
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Transport delivers the signed requests from the local party to other parties.
+type Transport interface {
+	// Submit delivers the request signed by the sender to the party. The party rejection should be returned as
+	// the error with codes.InvalidArgument or codes.PermissionDenied (failed peer authentication) status,
+	// any other error means that the request has not been delivered (see rejected).
+	Submit(ctx context.Context, sender *secret.TssSecret, party *rarimo.Party, request *types.MsgSubmitRequest) error
+}
+
+// Receiver handles the requests delivered to the party. Implemented by core.SessionManager.
+type Receiver interface {
+	Receive(ctx context.Context, request *types.MsgSubmitRequest) error
+}
+
+// GRPCTransport submits requests to the party service over the buffered gRPC connections.
+// The sender secret is used to authenticate the connection with mutual TLS.
+type GRPCTransport struct{}
+
+func NewGRPCTransport() *GRPCTransport {
+	return &GRPCTransport{}
+}
+
+var _ Transport = &GRPCTransport{}
+
+func (g *GRPCTransport) Submit(ctx context.Context, sender *secret.TssSecret, party *rarimo.Party, request *types.MsgSubmitRequest) error {
+	client, err := clientsBuffer.get(party, sender)
+	if err != nil {
+		return err
+	}
+
+	return client.submit(ctx, request)
+}
+
+// rejected returns true if the request has been delivered to the party and rejected by it.
+// Failed peer authentication is also considered as rejection: the party is online, but does not accept the connection
+// (for example, while the parties are switching to mutual TLS).
+func rejected(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && (st.Code() == codes.InvalidArgument || st.Code() == codes.PermissionDenied)
+}
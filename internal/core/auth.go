@@ -9,6 +9,7 @@ import (
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
 	"github.com/rarimo/tss-svc/pkg/types"
 	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
@@ -31,6 +32,24 @@ func NewRequestAuthorizer(parties []*rarimo.Party, log *logan.Entry) *RequestAut
 }
 
 func (r *RequestAuthorizer) Auth(request *types.MsgSubmitRequest) (*rarimo.Party, error) {
+	pub, err := RecoverSigner(request)
+	if err != nil {
+		r.log.WithError(err).Debug("Failed to recover request signer")
+		return nil, ErrInvalidSignature
+	}
+
+	// TODO optimize: make log(n)
+	for _, p := range r.parties {
+		if bytes.Equal(hexutil.MustDecode(p.PubKey), pub) {
+			return p, nil
+		}
+	}
+
+	return nil, ErrSignerNotAParty
+}
+
+// RecoverSigner verifies the request signature and returns the signer public key (uncompressed point without 0x04 prefix).
+func RecoverSigner(request *types.MsgSubmitRequest) ([]byte, error) {
 	details, err := anypb.New(request.Data)
 	if err != nil {
 		return nil, err
@@ -40,28 +59,18 @@ func (r *RequestAuthorizer) Auth(request *types.MsgSubmitRequest) (*rarimo.Party
 
 	signature, err := hexutil.Decode(request.Signature)
 	if err != nil {
-		r.log.WithError(err).Debug("Failed to decode signature")
-		return nil, ErrInvalidSignature
+		return nil, errors.Wrap(err, "failed to decode signature")
 	}
 
 	pub, err := crypto.Ecrecover(hash, signature)
 	if err != nil {
-		r.log.WithError(err).Debug("Failed to recover signature public key")
-		return nil, ErrInvalidSignature
+		return nil, errors.Wrap(err, "failed to recover signature public key")
 	}
 
 	// Signature is in 65 bytes format [R|S|V]. VerifySignature accepts [R|S]
 	if !crypto.VerifySignature(pub, hash, signature[:64]) {
-		r.log.Debug("Failed to verify signature for recovered public key")
 		return nil, ErrInvalidSignature
 	}
 
-	// TODO optimize: make log(n)
-	for _, p := range r.parties {
-		if bytes.Equal(hexutil.MustDecode(p.PubKey), pub[1:]) {
-			return p, nil
-		}
-	}
-
-	return nil, ErrSignerNotAParty
+	return pub[1:], nil
 }
@@ -9,6 +9,7 @@ import (
 // Default: 0-2 proposal 3-5 acceptance 6-12 sign 13-15 finish
 // Keygen: 0-10 11-13 finish
 // Reshare 0-2 proposal 3-5 acceptance 6-30 keygen 31-37 sign 38-44 sign 45-47 finish
+// Used by default if timeline is not configured for the session type.
 const (
 	DefaultSessionDuration           = 15
 	DefaultSessionProposalDuration   = 2
@@ -36,51 +37,25 @@ type BoundsManager struct {
 	SessionStart         uint64
 	SessionEnd           uint64
 	SessionDuration      uint64
+	SessionInterval      uint64
 	durationByController map[types.ControllerType]uint64
 	bounds               []*Bounds
 }
 
-func NewBoundsManager(start uint64, sessionType types.SessionType) *BoundsManager {
-	switch sessionType {
-	case types.SessionType_DefaultSession:
-		return &BoundsManager{
-			SessionStart:    start,
-			SessionDuration: DefaultSessionDuration,
-			SessionEnd:      start + DefaultSessionDuration,
-			bounds:          make([]*Bounds, 0, 4),
-			durationByController: map[types.ControllerType]uint64{
-				types.ControllerType_CONTROLLER_PROPOSAL:   DefaultSessionProposalDuration,
-				types.ControllerType_CONTROLLER_ACCEPTANCE: DefaultSessionAcceptanceDuration,
-				types.ControllerType_CONTROLLER_SIGN:       DefaultSessionSignDuration,
-			},
-		}
-	case types.SessionType_KeygenSession:
-		return &BoundsManager{
-			SessionStart:    start,
-			SessionDuration: KeygenSessionDuration,
-			SessionEnd:      start + KeygenSessionDuration,
-			bounds:          make([]*Bounds, 0, 2),
-			durationByController: map[types.ControllerType]uint64{
-				types.ControllerType_CONTROLLER_KEYGEN: KeygenSessionKeygenDuration,
-			},
-		}
-	case types.SessionType_ReshareSession:
-		return &BoundsManager{
-			SessionStart:    start,
-			SessionDuration: ReshareSessionDuration,
-			SessionEnd:      start + ReshareSessionDuration,
-			bounds:          make([]*Bounds, 0, 6),
-			durationByController: map[types.ControllerType]uint64{
-				types.ControllerType_CONTROLLER_PROPOSAL:   ReshareSessionProposalDuration,
-				types.ControllerType_CONTROLLER_ACCEPTANCE: ReshareSessionAcceptanceDuration,
-				types.ControllerType_CONTROLLER_KEYGEN:     ReshareSessionKeygenDuration,
-				types.ControllerType_CONTROLLER_SIGN:       ReshareSessionSignDuration,
-			},
-		}
+func NewBoundsManager(start uint64, timeline *Timeline) *BoundsManager {
+	return &BoundsManager{
+		SessionStart:         start,
+		SessionDuration:      timeline.SessionDuration,
+		SessionInterval:      timeline.Interval(),
+		SessionEnd:           start + timeline.SessionDuration,
+		bounds:               make([]*Bounds, 0, len(timeline.DurationByController)+1),
+		durationByController: timeline.DurationByController,
 	}
+}
 
-	// Should not appear
-	panic("Invalid session type")
+// NextSessionStart returns the block the next session starts on
+func (b *BoundsManager) NextSessionStart() uint64 {
+	return b.SessionStart + b.SessionInterval
 }
 
 func (b *BoundsManager) NextController(t types.ControllerType) *Bounds {
@@ -112,3 +87,22 @@ func (b *BoundsManager) Current() *Bounds {
 		End:   b.SessionStart + b.SessionDuration,
 	}
 }
+
+// FinishCurrent shortens the current controller bounds to end on the provided height.
+// Used if controller has finished its logic before its bounds end. The session end remains the same.
+func (b *BoundsManager) FinishCurrent(height uint64) {
+	if len(b.bounds) > 0 && b.bounds[len(b.bounds)-1].End > height {
+		b.bounds[len(b.bounds)-1].End = height
+	}
+}
+
+// Resume marks the session blocks before the provided one as passed, so the next controller starts on the provided block.
+// Used to resume the restored session from the certain controller.
+func (b *BoundsManager) Resume(start uint64) {
+	if start > b.SessionStart {
+		b.bounds = append(b.bounds, &Bounds{
+			Start: b.SessionStart,
+			End:   start - 1,
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"context"
+
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/pkg/types"
+)
+
+const (
+	// RequestBufferSize defines the maximum amount of requests that can be buffered during the session
+	RequestBufferSize = 1024
+	// RequestBufferSenderSize defines the maximum amount of requests from one party that can be buffered during the session
+	RequestBufferSenderSize = 128
+)
+
+// RequestBuffer stores the requests that are not accepted by the current session controller to deliver them after
+// the corresponding controller launch. Parties that have finished the current controller earlier can send
+// the requests for the next one while self party is still running the current controller.
+// Only authorized requests should be buffered. Every sender has its own quota, so one party can not fill the buffer.
+// RequestBuffer is not thread-safe and should be used under the session lock.
+type RequestBuffer struct {
+	requests []bufferedRequest
+	counts   map[string]int
+}
+
+type bufferedRequest struct {
+	sender  string
+	request *types.MsgSubmitRequest
+}
+
+func NewRequestBuffer() *RequestBuffer {
+	return &RequestBuffer{
+		requests: make([]bufferedRequest, 0),
+		counts:   make(map[string]int),
+	}
+}
+
+// Push adds the request of the authorized sender to the buffer. Returns false if the buffer or sender quota is full.
+func (b *RequestBuffer) Push(sender *rarimo.Party, request *types.MsgSubmitRequest) bool {
+	if len(b.requests) >= RequestBufferSize || b.counts[sender.Account] >= RequestBufferSenderSize {
+		return false
+	}
+
+	b.counts[sender.Account]++
+	b.requests = append(b.requests, bufferedRequest{sender: sender.Account, request: request})
+	return true
+}
+
+// Flush delivers all buffered requests accepted by the controller. Other requests remain in the buffer.
+func (b *RequestBuffer) Flush(c context.Context, controller IController) {
+	ctx := core.WrapCtx(c)
+	rest := make([]bufferedRequest, 0, len(b.requests))
+
+	for _, buffered := range b.requests {
+		if !Accepts(controller, buffered.request) {
+			rest = append(rest, buffered)
+			continue
+		}
+
+		b.counts[buffered.sender]--
+		if err := controller.Receive(c, buffered.request); err != nil {
+			ctx.Log().WithError(err).Error("Error delivering buffered request")
+		}
+	}
+
+	b.requests = rest
+}
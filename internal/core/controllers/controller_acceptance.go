@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
 	"github.com/rarimo/tss-svc/internal/connectors"
 	"github.com/rarimo/tss-svc/internal/core"
 	"github.com/rarimo/tss-svc/pkg/types"
@@ -13,24 +14,29 @@ import (
 // iAcceptanceController defines custom logic for every acceptance controller.
 type iAcceptanceController interface {
 	Next() IController
-	validate(ctx core.Context, details *anypb.Any, st types.SessionType) bool
+	validate(ctx core.Context, sender *rarimo.Party, details *anypb.Any, st types.SessionType) bool
 	shareAcceptance(ctx core.Context)
 	updateSessionData(ctx core.Context)
 	finish(ctx core.Context)
+	pending() bool
 }
 
 // AcceptanceController is responsible for sharing and collecting acceptances for different types of session.
 type AcceptanceController struct {
 	iAcceptanceController
-	mu   sync.Mutex
-	wg   *sync.WaitGroup
-	data *LocalSessionData
-	auth *core.RequestAuthorizer
+	mu     sync.Mutex
+	wg     *sync.WaitGroup
+	data   *LocalSessionData
+	auth   *core.RequestAuthorizer
+	shared bool
 }
 
 // Implements IController interface
 var _ IController = &AcceptanceController{}
 
+// Implements ICompletable interface
+var _ ICompletable = &AcceptanceController{}
+
 // Run initiates sharing of the acceptances with other parties.
 // Should be launched only in case of valid proposal (session processing should be `true`)
 func (a *AcceptanceController) Run(c context.Context) {
@@ -55,7 +61,7 @@ func (a *AcceptanceController) Receive(c context.Context, request *types.MsgSubm
 		return ErrInvalidRequestType
 	}
 
-	if !a.validate(ctx, request.Data.Details, request.Data.SessionType) {
+	if !a.validate(ctx, sender, request.Data.Details, request.Data.SessionType) {
 		a.data.Offenders[sender.Account] = struct{}{}
 		return nil
 	}
@@ -73,6 +79,26 @@ func (a *AcceptanceController) WaitFor() {
 	a.wg.Wait()
 }
 
+// Done returns true if self acceptance has been shared and acceptances from all other parties have been received.
+func (a *AcceptanceController) Done() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.shared || a.pending() {
+		return false
+	}
+
+	ctx := a.data.Context()
+	self := ctx.SecretStorage().GetTssSecret().AccountAddress()
+	for _, party := range a.data.Set.Parties {
+		if _, ok := a.data.Acceptances[party.Account]; !ok && party.Account != self {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (a *AcceptanceController) Type() types.ControllerType {
 	return types.ControllerType_CONTROLLER_ACCEPTANCE
 }
@@ -84,20 +110,31 @@ func (a *AcceptanceController) run(ctx core.Context) {
 		a.wg.Done()
 	}()
 
-	a.shareAcceptance(ctx)
+	// observer only collects the acceptances of other parties
+	if !a.data.Observer {
+		a.shareAcceptance(ctx)
+	}
+
+	a.mu.Lock()
+	a.shared = true
+	a.mu.Unlock()
+
 	<-ctx.Context().Done()
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	// adding self
-	a.data.Acceptances[ctx.SecretStorage().GetTssSecret().AccountAddress()] = struct{}{}
+	if !a.data.Observer {
+		a.data.Acceptances[ctx.SecretStorage().GetTssSecret().AccountAddress()] = struct{}{}
+	}
 
 	ctx.Log().Infof("Received acceptances list: %v", a.data.Acceptances)
 
 	// report for parties that has not voted for accepted proposal
+	// (acceptances received before restart are lost, so restored session does not report anybody)
 	for _, party := range a.data.Set.Parties {
-		if _, ok := a.data.Acceptances[party.Account]; !ok {
+		if _, ok := a.data.Acceptances[party.Account]; !ok && !a.data.Restored {
 			a.data.Offenders[party.Account] = struct{}{}
 		}
 	}
@@ -124,7 +161,7 @@ func (a *defaultAcceptanceController) Next() IController {
 	return a.data.GetFinishController()
 }
 
-func (a *defaultAcceptanceController) validate(ctx core.Context, any *anypb.Any, st types.SessionType) bool {
+func (a *defaultAcceptanceController) validate(ctx core.Context, _ *rarimo.Party, any *anypb.Any, st types.SessionType) bool {
 	if st != types.SessionType_DefaultSession {
 		return false
 	}
@@ -144,7 +181,7 @@ func (a *defaultAcceptanceController) shareAcceptance(ctx core.Context) {
 		return
 	}
 
-	go a.broadcast.SubmitAllWithReport(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
+	a.broadcast.SubmitAllWithReportAsync(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
 		Data: &types.RequestData{
 			SessionType: types.SessionType_DefaultSession,
 			Type:        types.RequestType_Acceptance,
@@ -174,6 +211,11 @@ func (a *defaultAcceptanceController) updateSessionData(ctx core.Context) {
 	}
 }
 
+// pending returns true if self acceptance sharing has not been finished yet.
+func (a *defaultAcceptanceController) pending() bool {
+	return a.broadcast.Pending()
+}
+
 // finish verifies that results satisfies the requirements (t + 1 acceptances) and calculates the signature producers set.
 func (a *defaultAcceptanceController) finish(ctx core.Context) {
 	// T+1 required for signing
@@ -197,8 +239,24 @@ func (a *defaultAcceptanceController) finish(ctx core.Context) {
 
 // reshareAcceptanceController represents custom logic for types.SessionType_ReshareSession
 type reshareAcceptanceController struct {
+	mu        sync.Mutex
 	data      *LocalSessionData
 	broadcast *connectors.BroadcastConnector
+	// generation of the current key share. Party that does not hold the key share
+	// takes it from the acceptances of verified parties.
+	generation    uint64
+	generationSet bool
+	// eddsaShares contains the EdDSA key share states of the active parties. EdDSA key is reshared only
+	// if all active parties hold the same EdDSA key of the same generation.
+	eddsaShares map[string]eddsaShare
+	// refused is true if self party has refused to accept the session without fresh pre-params
+	refused bool
+}
+
+// eddsaShare describes the EdDSA key share state of the party shared in the reshare acceptance.
+type eddsaShare struct {
+	key        string
+	generation uint64
 }
 
 // Implements iAcceptanceController interface
@@ -206,15 +264,16 @@ var _ iAcceptanceController = &reshareAcceptanceController{}
 
 // Next method returns the next controller instance to be launched. If controller finished successfully
 // the next controller will be a keygen controller. Otherwise, it will be a finish controller.
+// Observer does not take part in keygen, so it always goes to the finish controller.
 func (a *reshareAcceptanceController) Next() IController {
-	if a.data.Processing {
+	if a.data.Processing && !a.data.Observer {
 		return a.data.GetKeygenController()
 	}
 
 	return a.data.GetFinishController()
 }
 
-func (a *reshareAcceptanceController) validate(ctx core.Context, any *anypb.Any, st types.SessionType) bool {
+func (a *reshareAcceptanceController) validate(ctx core.Context, sender *rarimo.Party, any *anypb.Any, st types.SessionType) bool {
 	if st != types.SessionType_ReshareSession {
 		return false
 	}
@@ -224,17 +283,95 @@ func (a *reshareAcceptanceController) validate(ctx core.Context, any *anypb.Any,
 		ctx.Log().WithError(err).Error("Error unmarshalling request")
 	}
 
-	return checkSet(details.New, a.data.Set)
+	if !checkSet(details.New, a.data.Set) || !a.checkGeneration(ctx, sender, details.Generation) {
+		return false
+	}
+
+	a.addEdDSAShare(sender, eddsaShare{key: details.EddsaKey, generation: details.EddsaGeneration})
+	return true
+}
+
+// checkGeneration verifies that verified parties share the same key generation.
+// Party that does not hold the key share accepts the generation of the first verified party.
+func (a *reshareAcceptanceController) checkGeneration(ctx core.Context, sender *rarimo.Party, generation uint64) bool {
+	// Inactive parties do not hold the key share
+	if sender.Status != rarimo.PartyStatus_Active {
+		return true
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.generationSet {
+		ctx.Log().Infof("Key generation %d received from %s", generation, sender.Account)
+		a.generation = generation
+		a.generationSet = true
+		return true
+	}
+
+	return a.generation == generation
+}
+
+// addEdDSAShare stores the EdDSA key share state of the active party.
+func (a *reshareAcceptanceController) addEdDSAShare(sender *rarimo.Party, share eddsaShare) {
+	// Inactive parties do not hold the key share
+	if sender.Status != rarimo.PartyStatus_Active {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.eddsaShares[sender.Account]; !ok {
+		a.eddsaShares[sender.Account] = share
+	}
+}
+
+// eddsaKey returns the EdDSA key share state agreed by all active parties. Returns empty state if parties
+// do not hold the EdDSA key or disagree on it: new EdDSA key will be generated in such case.
+func (a *reshareAcceptanceController) eddsaKey(ctx core.Context) eddsaShare {
+	var agreed *eddsaShare
+	for account, share := range a.eddsaShares {
+		if agreed == nil {
+			agreed = &share
+			continue
+		}
+
+		if *agreed != share {
+			ctx.Log().Warnf("Party %s holds another EdDSA key share, EdDSA key will be generated from scratch", account)
+			return eddsaShare{}
+		}
+	}
+
+	if agreed == nil {
+		return eddsaShare{}
+	}
+
+	return *agreed
 }
 
+// shareAcceptance shares self acceptance if the party holds fresh pre-params for the new key share.
+// Otherwise, the party refuses to accept the session: resharing requires all parties, so the session fails
+// for everyone and other parties report the missing acceptance.
 func (a *reshareAcceptanceController) shareAcceptance(ctx core.Context) {
-	details, err := anypb.New(&types.ReshareSessionAcceptanceData{New: getSet(a.data.Set)})
+	if ctx.SecretStorage().PreParamsPoolSize() == 0 {
+		ctx.Log().WithError(ErrNoFreshPreParams).Error("Refusing to accept reshare session without fresh pre-params")
+		a.refused = true
+		return
+	}
+
+	details, err := anypb.New(&types.ReshareSessionAcceptanceData{
+		New:             getSet(a.data.Set),
+		Generation:      ctx.SecretStorage().GetTssSecret().Generation(),
+		EddsaKey:        ctx.SecretStorage().GetTssSecret().GlobalEdDSAPubKey(),
+		EddsaGeneration: ctx.SecretStorage().GetTssSecret().EdDSAGeneration(),
+	})
 	if err != nil {
 		ctx.Log().WithError(err).Error("Error parsing details")
 		return
 	}
 
-	go a.broadcast.SubmitAllWithReport(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
+	a.broadcast.SubmitAllWithReportAsync(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
 		Data: &types.RequestData{
 			SessionType: types.SessionType_ReshareSession,
 			Type:        types.RequestType_Acceptance,
@@ -249,14 +386,25 @@ func (a *reshareAcceptanceController) updateSessionData(ctx core.Context) {
 	// Nothing to do for reshare session
 }
 
-// finish verifies that results satisfies the requirements (all accepted) and calculates the
-// signature producers set (based on old parties).
+// pending returns true if self acceptance sharing has not been finished yet.
+func (a *reshareAcceptanceController) pending() bool {
+	return a.broadcast.Pending()
+}
+
+// finish verifies that results satisfies the requirements (all accepted and self acceptance has not been refused)
+// and calculates the signature producers set (based on old parties).
 func (a *reshareAcceptanceController) finish(ctx core.Context) {
-	if len(a.data.Acceptances) < a.data.Set.N {
+	if a.refused || len(a.data.Acceptances) < a.data.Set.N {
 		a.data.Processing = false
 		return
 	}
 
+	a.data.Generation = a.generation
+
+	eddsa := a.eddsaKey(ctx)
+	a.data.EdDSAKey = eddsa.key
+	a.data.EdDSAGeneration = eddsa.generation
+
 	defer func() {
 		ctx.Log().Infof("Session signers: %v", acceptancesToArr(a.data.Signers))
 		_, a.data.IsSigner = a.data.Signers[ctx.SecretStorage().GetTssSecret().AccountAddress()]
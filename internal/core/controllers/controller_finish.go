@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"database/sql"
 	"sync"
 
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
@@ -30,24 +31,33 @@ func (f *FinishController) Receive(context.Context, *types.MsgSubmitRequest) err
 }
 
 // Run initiates the report submitting for all parties that was included into Offenders set. After it executes the
-// `iFinishController.finish` logic.
+// `iFinishController.finish` logic. Parties blamed by the tss protocol are reported with their own violation type and message.
+// In observer mode nothing is submitted and only unsuccessful sessions are finalized in the database:
+// observer can not check the signing results, so the accepted sessions are left in processing status.
 func (f *FinishController) Run(c context.Context) {
 	ctx := core.WrapCtx(c)
 	ctx.Log().Infof("Starting: %s", f.Type().String())
 	f.wg.Add(1)
 	defer func() {
 		ctx.Log().Infof("Finishing: %s", f.Type().String())
-		f.updateSessionEntry(ctx)
+		if !f.data.Observer || !f.data.Processing {
+			f.updateSessionEntry(ctx)
+		}
 		f.wg.Done()
 	}()
 
+	if f.data.Observer {
+		ctx.Log().Infof("Observer mode. Session offenders: %v", acceptancesToArr(f.data.Offenders))
+		return
+	}
+
 	for offender := range f.data.Offenders {
-		if err := ctx.Core().SubmitReport(
-			f.data.SessionId,
-			rarimo.ViolationType_Spam,
-			offender,
-			"Party shared invalid data or have not accepted valid proposal",
-		); err != nil {
+		violation, message := rarimo.ViolationType_Spam, "Party shared invalid data or have not accepted valid proposal"
+		if culprit, ok := f.data.Culprits[offender]; ok {
+			violation, message = culprit.Type, culprit.Message
+		}
+
+		if err := ctx.Core().SubmitReport(f.data.SessionId, violation, offender, message); err != nil {
 			ctx.Log().WithError(err).Errorf("Error submitting violation report for party: %s", offender)
 		}
 	}
@@ -133,6 +143,8 @@ var _ iFinishController = &defaultFinishController{}
 // If true, it will share the generated signature via submitting confirmation message to the core.
 // In case of unsuccessful session the selected indexes will be returned to the pool.
 func (d *defaultFinishController) finish(ctx core.Context) {
+	ctx.Pool().Release(d.data.SessionId, d.data.Indexes)
+
 	if d.data.Processing {
 		ctx.Log().Infof("Session %s #%d finished successfully", d.data.SessionType.String(), d.data.SessionId)
 		if !d.data.IsSigner {
@@ -179,6 +191,11 @@ func (d *defaultFinishController) updateSessionEntry(ctx core.Context) {
 		session.Status = int(types.SessionStatus_SessionFailed)
 	}
 
+	session.SignatureFailure = sql.NullString{
+		String: d.data.SignatureFailure,
+		Valid:  d.data.SignatureFailure != "",
+	}
+
 	if err := ctx.PG().DefaultSessionDatumQ().Update(session); err != nil {
 		ctx.Log().Error("Error updating session entry")
 	}
@@ -252,6 +269,11 @@ func (r *reshareFinishController) updateSessionEntry(ctx core.Context) {
 		session.Status = int(types.SessionStatus_SessionFailed)
 	}
 
+	session.SignatureFailure = sql.NullString{
+		String: r.data.SignatureFailure,
+		Valid:  r.data.SignatureFailure != "",
+	}
+
 	if err := ctx.PG().ReshareSessionDatumQ().Update(session); err != nil {
 		ctx.Log().Error("Error updating session entry")
 	}
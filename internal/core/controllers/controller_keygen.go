@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/elliptic"
 	"database/sql"
+	"fmt"
 	"sync"
 
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth "github.com/ethereum/go-ethereum/crypto"
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
@@ -19,38 +21,66 @@ import (
 type iKeygenController interface {
 	Next() IController
 	updateSessionData(ctx core.Context)
-	finish(ctx core.Context, result *keygen.LocalPartySaveData)
+	finish(ctx core.Context, result *keygen.LocalPartySaveData, eddsaResult *eddsakeygen.LocalPartySaveData)
+}
+
+// keygenParty defines the tss party that produces the ECDSA or EdDSA key share by new key generation or by resharing.
+type keygenParty interface {
+	Receive(sender *rarimo.Party, request *types.MsgSubmitRequest, details []byte) error
+	Echo(sender *rarimo.Party, request *types.MsgSubmitRequest) error
+	Run(ctx context.Context)
+	WaitFor()
+	Done() bool
+	Result() *keygen.LocalPartySaveData
+	EdDSAResult() *eddsakeygen.LocalPartySaveData
+	Culprits() []tss.Culprit
 }
 
 // KeygenController is responsible for initial key generation. It can only be launched with empty secret storage and
 // after finishing will update storage with generated secret.
+// ECDSA and EdDSA keys are generated simultaneously by two independent tss parties.
 type KeygenController struct {
 	iKeygenController
-	wg    *sync.WaitGroup
-	data  *LocalSessionData
-	auth  *core.RequestAuthorizer
-	party *tss.KeygenParty
+	wg         *sync.WaitGroup
+	data       *LocalSessionData
+	auth       *core.RequestAuthorizer
+	party      keygenParty
+	eddsaParty keygenParty
 }
 
 // Implements IController interface
 var _ IController = &KeygenController{}
 
+// Implements ICompletable interface
+var _ ICompletable = &KeygenController{}
+
 // Receive accepts the keygen requests from other parties and delivers them to the `tss.KeygenParty`
+// corresponding to the request key scheme.
 func (k *KeygenController) Receive(c context.Context, request *types.MsgSubmitRequest) error {
 	sender, err := k.auth.Auth(request)
 	if err != nil {
 		return err
 	}
 
-	if request.Data.Type != types.RequestType_Keygen {
+	party := k.party
+	if request.Data.Scheme == types.KeyScheme_EdDSA {
+		party = k.eddsaParty
+	}
+
+	switch request.Data.Type {
+	case types.RequestType_Keygen:
+		err = party.Receive(sender, request, request.Data.Details.Value)
+	case types.RequestType_Echo:
+		err = party.Echo(sender, request)
+	default:
 		return ErrInvalidRequestType
 	}
 
-	if err := k.party.Receive(sender, request.Data.IsBroadcast, request.Data.Details.Value); err != nil {
+	if err != nil {
 		ctx := core.WrapCtx(c)
 		ctx.Log().WithError(err).Error("failed to receive request on party")
 		// can be done without lock: no remove or change operation exist, only add
-		k.data.Offenders[sender.Account] = struct{}{}
+		k.data.blame(sender, err)
 	}
 
 	return nil
@@ -62,6 +92,7 @@ func (k *KeygenController) Run(c context.Context) {
 	ctx := core.WrapCtx(c)
 	ctx.Log().Infof("Starting: %s", k.Type().String())
 	k.party.Run(c)
+	k.eddsaParty.Run(c)
 	k.wg.Add(1)
 	go k.run(ctx)
 }
@@ -71,6 +102,11 @@ func (k *KeygenController) WaitFor() {
 	k.wg.Wait()
 }
 
+// Done returns true if both tss parties have produced the keys and sent all their messages.
+func (k *KeygenController) Done() bool {
+	return k.party.Done() && k.eddsaParty.Done()
+}
+
 func (k *KeygenController) Type() types.ControllerType {
 	return types.ControllerType_CONTROLLER_KEYGEN
 }
@@ -84,6 +120,10 @@ func (k *KeygenController) run(ctx core.Context) {
 
 	<-ctx.Context().Done()
 	k.party.WaitFor()
+	k.eddsaParty.WaitFor()
+
+	k.data.addCulprits(k.party.Culprits())
+	k.data.addCulprits(k.eddsaParty.Culprits())
 
 	result := k.party.Result()
 	if result == nil {
@@ -91,7 +131,13 @@ func (k *KeygenController) run(ctx core.Context) {
 		return
 	}
 
-	k.finish(ctx, result)
+	// EdDSA key is optional: session does not fail if only EdDSA keygen has not been finished
+	eddsaResult := k.eddsaParty.EdDSAResult()
+	if eddsaResult == nil {
+		ctx.Log().Warn("EdDSA key has not been generated")
+	}
+
+	k.finish(ctx, result, eddsaResult)
 }
 
 // defaultKeygenController represents custom logic for types.SessionType_KeygenSession
@@ -130,6 +176,10 @@ func (d *defaultKeygenController) updateSessionData(ctx core.Context) {
 		String: d.data.NewSecret.GlobalPubKey(),
 		Valid:  d.data.Processing,
 	}
+	session.EddsaKey = sql.NullString{
+		String: d.data.NewSecret.GlobalEdDSAPubKey(),
+		Valid:  d.data.NewSecret.GlobalEdDSAPubKey() != "",
+	}
 
 	if err = ctx.PG().KeygenSessionDatumQ().Update(session); err != nil {
 		ctx.Log().WithError(err).Error("Error updating session entry")
@@ -137,8 +187,8 @@ func (d *defaultKeygenController) updateSessionData(ctx core.Context) {
 }
 
 // finish sets up new secret in data (without storing it in secret store).
-func (d *defaultKeygenController) finish(ctx core.Context, result *keygen.LocalPartySaveData) {
-	d.data.NewSecret = ctx.SecretStorage().GetTssSecret().NewWithData(result)
+func (d *defaultKeygenController) finish(ctx core.Context, result *keygen.LocalPartySaveData, eddsaResult *eddsakeygen.LocalPartySaveData) {
+	d.data.NewSecret = ctx.SecretStorage().GetTssSecret().NewWithData(result).NewWithEdDSAData(eddsaResult)
 	d.data.Processing = true
 }
 
@@ -182,6 +232,10 @@ func (r *reshareKeygenController) updateSessionData(ctx core.Context) {
 		String: r.data.NewSecret.GlobalPubKey(),
 		Valid:  r.data.Processing,
 	}
+	session.NewEddsaKey = sql.NullString{
+		String: r.data.NewSecret.GlobalEdDSAPubKey(),
+		Valid:  r.data.NewSecret.GlobalEdDSAPubKey() != "",
+	}
 
 	if err = ctx.PG().ReshareSessionDatumQ().Update(session); err != nil {
 		ctx.Log().WithError(err).Error("Error updating session data entry")
@@ -189,11 +243,40 @@ func (r *reshareKeygenController) updateSessionData(ctx core.Context) {
 }
 
 // finish sets up new secret in data (without storing it in secret store) and calculates new parties ECDSA public keys.
-func (r *reshareKeygenController) finish(ctx core.Context, result *keygen.LocalPartySaveData) {
-	r.data.NewSecret = ctx.SecretStorage().GetTssSecret().NewWithData(result)
+// Resharing keeps the global public key, so the result with another key is considered invalid.
+// If EdDSA key has not been reshared or has another public key, the current EdDSA key share is kept
+// and EdDSA resharing is reported as failed.
+func (r *reshareKeygenController) finish(ctx core.Context, result *keygen.LocalPartySaveData, eddsaResult *eddsakeygen.LocalPartySaveData) {
+	generation := r.data.Generation + 1
+
+	// New EdDSA key has the zero generation, reshared one gets the next generation
+	var eddsaGeneration uint64
+	if r.data.EdDSAKey != "" {
+		eddsaGeneration = r.data.EdDSAGeneration + 1
+	}
+
+	newSecret := ctx.SecretStorage().GetTssSecret().NewWithResharedData(result, generation)
+	if eddsaResult == nil {
+		r.failEdDSA(ctx, "EdDSA party has not produced the key share")
+	} else {
+		eddsaSecret := newSecret.NewWithResharedEdDSAData(eddsaResult, eddsaGeneration)
+		if r.data.EdDSAKey != "" && eddsaSecret.GlobalEdDSAPubKey() != r.data.EdDSAKey {
+			r.failEdDSA(ctx, fmt.Sprintf("reshared EdDSA key %s does not match the current key %s", eddsaSecret.GlobalEdDSAPubKey(), r.data.EdDSAKey))
+		} else {
+			newSecret = eddsaSecret
+		}
+	}
+
+	if newSecret.GlobalPubKey() != r.data.Set.GlobalPubKey {
+		ctx.Log().Errorf("Reshared key %s does not match the current key %s", newSecret.GlobalPubKey(), r.data.Set.GlobalPubKey)
+		r.data.Processing = false
+		return
+	}
+
+	r.data.NewSecret = newSecret
 	r.data.NewParties = make([]*rarimo.Party, len(r.data.Set.Parties))
 
-	partyIDs := core.PartyIds(r.data.Set.Parties)
+	partyIDs := core.ShareIds(r.data.Set.Parties, generation)
 	for i := range result.Ks {
 		partyId := partyIDs.FindByKey(result.Ks[i])
 		for j, party := range r.data.Set.Parties {
@@ -212,3 +295,9 @@ func (r *reshareKeygenController) finish(ctx core.Context, result *keygen.LocalP
 		}
 	}
 }
+
+// failEdDSA reports the failed EdDSA resharing. EdDSA key is optional, so the session is not failed.
+func (r *reshareKeygenController) failEdDSA(ctx core.Context, reason string) {
+	r.data.SignatureFailure = fmt.Sprintf("EdDSA resharing failed: %s", reason)
+	ctx.Log().Errorf("EdDSA resharing failed, current EdDSA key share is kept: %s", reason)
+}
@@ -23,6 +23,7 @@ type iProposalController interface {
 	accept(ctx core.Context, details *anypb.Any, st types.SessionType) bool
 	shareProposal(ctx core.Context)
 	updateSessionData(ctx core.Context)
+	done() bool
 }
 
 // ProposalController is responsible for proposing and collecting proposals from proposer.
@@ -37,6 +38,9 @@ type ProposalController struct {
 // Implements IController interface
 var _ IController = &ProposalController{}
 
+// Implements ICompletable interface
+var _ ICompletable = &ProposalController{}
+
 // Receive accepts proposal from other parties. It will check that proposal was submitted from the selected session proposer.
 // After it will execute the `iProposalController.accept` logic.
 func (p *ProposalController) Receive(c context.Context, request *types.MsgSubmitRequest) error {
@@ -88,6 +92,11 @@ func (p *ProposalController) Next() IController {
 	return p.data.GetFinishController()
 }
 
+// Done returns true if the proposal has been accepted or has been shared with all parties by self proposer.
+func (p *ProposalController) Done() bool {
+	return p.done()
+}
+
 func (p *ProposalController) Type() types.ControllerType {
 	return types.ControllerType_CONTROLLER_PROPOSAL
 }
@@ -101,6 +110,11 @@ func (p *ProposalController) run(ctx core.Context) {
 
 	ctx.Log().Debugf("Session %s %d proposer: %v", p.data.SessionType.String(), p.data.SessionId, p.data.Proposer)
 
+	if p.data.Observer {
+		ctx.Log().Debug("Observer mode. No actions required")
+		return
+	}
+
 	if p.data.Proposer.Account != ctx.SecretStorage().GetTssSecret().AccountAddress() {
 		ctx.Log().Debug("Proposer is another party. No actions required")
 		return
@@ -149,17 +163,22 @@ func (d *defaultProposalController) accept(ctx core.Context, details *anypb.Any,
 		return false
 	}
 
-	if hexutil.Encode(merkle.NewTree(eth.Keccak256, contents...).Root()) == data.Root {
-		d.mu.Lock()
-		defer d.mu.Unlock()
-		ctx.Log().Infof("Proposal data is correct. Proposal accepted.")
-		d.data.Processing = true
-		d.data.Root = data.Root
-		d.data.Indexes = data.Indexes
-		return true
+	if hexutil.Encode(merkle.NewTree(eth.Keccak256, contents...).Root()) != data.Root {
+		return false
 	}
 
-	return false
+	if !ctx.Pool().Reserve(d.data.SessionId, data.Indexes) {
+		ctx.Log().Info("Proposal contains operations that are being signed in another session")
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ctx.Log().Infof("Proposal data is correct. Proposal accepted.")
+	d.data.Processing = true
+	d.data.Root = data.Root
+	d.data.Indexes = data.Indexes
+	return true
 }
 
 // shareProposal selects the operation indexes from the pool, constructs the proposal and share it between parties.
@@ -182,6 +201,12 @@ func (d *defaultProposalController) shareProposal(ctx core.Context) {
 		return
 	}
 
+	if !ctx.Pool().Reserve(d.data.SessionId, ids) {
+		ctx.Log().Info("Pool contains operations that are being signed in another session. Skipping.")
+		d.returnToPool(ctx, ids)
+		return
+	}
+
 	ctx.Log().Infof("Performed pool to share: %v", ids)
 
 	details, err := anypb.New(&types.DefaultSessionProposalData{Indexes: ids, Root: root})
@@ -190,7 +215,7 @@ func (d *defaultProposalController) shareProposal(ctx core.Context) {
 		return
 	}
 
-	go d.broadcast.SubmitAllWithReport(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
+	d.broadcast.SubmitAllWithReportAsync(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
 		Data: &types.RequestData{
 			SessionType: types.SessionType_DefaultSession,
 			Type:        types.RequestType_Proposal,
@@ -239,6 +264,22 @@ func (d *defaultProposalController) updateSessionData(ctx core.Context) {
 	}
 }
 
+// done returns true if the proposal has been accepted or shared.
+func (d *defaultProposalController) done() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.data.Processing && !d.broadcast.Pending()
+}
+
+func (d *defaultProposalController) returnToPool(ctx core.Context, ids []string) {
+	for _, index := range ids {
+		if err := ctx.Pool().Add(index); err != nil {
+			ctx.Log().WithError(err).Errorf("failed to return index %s to the pool", index)
+		}
+	}
+}
+
+// getNewPool selects the operations to sign from the pool. Operations that are being signed in other sessions are skipped.
 func (d *defaultProposalController) getNewPool(ctx core.Context) ([]string, string, error) {
 	ids, err := ctx.Pool().GetNext(MaxPoolSize)
 	if err != nil {
@@ -315,7 +356,7 @@ func (r *reshareProposalController) shareProposal(ctx core.Context) {
 		return
 	}
 
-	go r.broadcast.SubmitAllWithReport(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
+	r.broadcast.SubmitAllWithReportAsync(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
 		Data: &types.RequestData{
 			SessionType: types.SessionType_ReshareSession,
 			Type:        types.RequestType_Proposal,
@@ -360,3 +401,10 @@ func (r *reshareProposalController) updateSessionData(ctx core.Context) {
 		ctx.Log().WithError(err).Error("Error updating session entry")
 	}
 }
+
+// done returns true if the proposal has been accepted or shared.
+func (r *reshareProposalController) done() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.data.Processing && !r.broadcast.Pending()
+}
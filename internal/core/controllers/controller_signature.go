@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -11,28 +12,38 @@ import (
 	"github.com/rarimo/tss-svc/internal/core"
 	"github.com/rarimo/tss-svc/internal/tss"
 	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
 	"gitlab.com/distributed_lab/logan/v3/errors"
 )
 
 // iSignatureController defines custom logic for every signature controller.
 type iSignatureController interface {
 	Next() IController
-	finish(signature string)
+	finish(signature, eddsaSignature string)
 	updateSessionData(ctx core.Context)
 }
 
 // SignatureController is responsible for signing data by signature producers.
+// If the EdDSA key is available the data is also signed by the EdDSA party simultaneously with ECDSA one.
+// Produced signatures are verified against the current keys before being shared with the next controllers.
 type SignatureController struct {
 	iSignatureController
-	wg    *sync.WaitGroup
-	data  *LocalSessionData
-	auth  *core.RequestAuthorizer
-	party *tss.SignParty
+	wg         *sync.WaitGroup
+	data       *LocalSessionData
+	auth       *core.RequestAuthorizer
+	party      *tss.SignParty
+	eddsaParty *tss.SignParty
+	// round is the name of the signing round used to record the verification failure
+	round       string
+	eddsaPubKey string
 }
 
 // Implements IController interface
 var _ IController = &SignatureController{}
 
+// Implements ICompletable interface
+var _ ICompletable = &SignatureController{}
+
 // Receive accepts the signature requests from other parties and delivers it to the `tss.SignParty.
 // If sender is not present in current signers set request will not be accepted.
 func (s *SignatureController) Receive(c context.Context, request *types.MsgSubmitRequest) error {
@@ -50,6 +61,10 @@ func (s *SignatureController) Receive(c context.Context, request *types.MsgSubmi
 		return ErrSenderIsNotSigner
 	}
 
+	if request.Data.Type == types.RequestType_Echo {
+		return s.receiveEcho(ctx, sender, request)
+	}
+
 	if request.Data.Type != types.RequestType_Sign {
 		return ErrInvalidRequestType
 	}
@@ -59,16 +74,45 @@ func (s *SignatureController) Receive(c context.Context, request *types.MsgSubmi
 		return errors.Wrap(err, "error unmarshalling request")
 	}
 
-	if sign.Data != s.party.Data() {
+	party := s.party
+	if request.Data.Scheme == types.KeyScheme_EdDSA {
+		if s.eddsaParty == nil {
+			ctx.Log().Debugf("Received EdDSA sign request from %s without EdDSA signing", sender.Account)
+			return nil
+		}
+
+		party = s.eddsaParty
+	}
+
+	if sign.Data != party.Data() {
 		ctx.Log().Debugf("Received sign data from %s does not corresponds required one", sender.Account)
 		s.data.Offenders[sender.Account] = struct{}{}
 		return nil
 	}
 
-	if err := s.party.Receive(sender, request.Data.IsBroadcast, sign.Details.Value); err != nil {
+	if err := party.Receive(sender, request, sign.Details.Value); err != nil {
 		ctx.Log().WithError(err).Error("failed to receive request on party")
 		// can be done without lock: no remove or change operation exist, only add
-		s.data.Offenders[sender.Account] = struct{}{}
+		s.data.blame(sender, err)
+	}
+
+	return nil
+}
+
+// receiveEcho delivers the broadcast request echoed by the sender to the party of the request scheme.
+func (s *SignatureController) receiveEcho(ctx core.Context, sender *rarimo.Party, request *types.MsgSubmitRequest) error {
+	party := s.party
+	if request.Data.Scheme == types.KeyScheme_EdDSA {
+		if s.eddsaParty == nil {
+			return nil
+		}
+
+		party = s.eddsaParty
+	}
+
+	if err := party.Echo(sender, request); err != nil {
+		ctx.Log().WithError(err).Error("failed to receive echo on party")
+		s.data.blame(sender, err)
 	}
 
 	return nil
@@ -80,6 +124,9 @@ func (s *SignatureController) Run(c context.Context) {
 	ctx := core.WrapCtx(c)
 	ctx.Log().Infof("Starting: %s", s.Type().String())
 	s.party.Run(c)
+	if s.eddsaParty != nil {
+		s.eddsaParty.Run(c)
+	}
 	s.wg.Add(1)
 	go s.run(ctx)
 }
@@ -89,6 +136,11 @@ func (s *SignatureController) WaitFor() {
 	s.wg.Wait()
 }
 
+// Done returns true if the tss parties have produced the signatures and sent all their messages.
+func (s *SignatureController) Done() bool {
+	return s.party.Done() && (s.eddsaParty == nil || s.eddsaParty.Done())
+}
+
 func (s *SignatureController) Type() types.ControllerType {
 	return types.ControllerType_CONTROLLER_SIGN
 }
@@ -103,6 +155,11 @@ func (s *SignatureController) run(ctx core.Context) {
 	<-ctx.Context().Done()
 
 	s.party.WaitFor()
+	s.data.addCulprits(s.party.Culprits())
+	if s.eddsaParty != nil {
+		s.eddsaParty.WaitFor()
+		s.data.addCulprits(s.eddsaParty.Culprits())
+	}
 
 	result := s.party.Result()
 	if result == nil {
@@ -111,7 +168,38 @@ func (s *SignatureController) run(ctx core.Context) {
 	}
 
 	signature := hexutil.Encode(append(result.Signature, result.SignatureRecovery...))
-	s.finish(signature)
+	if err := tss.VerifyECDSA(s.party.Data(), signature, s.data.Set.GlobalPubKey); err != nil {
+		s.fail(ctx, types.KeyScheme_ECDSA, signature, err)
+		return
+	}
+
+	// EdDSA signature is optional: session does not fail if only EdDSA signing has not been finished
+	var eddsaSignature string
+	if s.eddsaParty != nil {
+		if eddsaResult := s.eddsaParty.Result(); eddsaResult != nil {
+			eddsaSignature = hexutil.Encode(eddsaResult.Signature)
+			if err := tss.VerifyEdDSA(s.eddsaParty.Data(), eddsaSignature, s.eddsaPubKey); err != nil {
+				s.fail(ctx, types.KeyScheme_EdDSA, eddsaSignature, err)
+				return
+			}
+		} else {
+			ctx.Log().Warn("EdDSA signature has not been produced")
+		}
+	}
+
+	s.finish(signature, eddsaSignature)
+}
+
+// fail marks the session as failed because of the invalid signature produced by the tss party.
+// Invalid signatures are never shared with the next controllers, so they will not be submitted to the core.
+func (s *SignatureController) fail(ctx core.Context, scheme types.KeyScheme, signature string, err error) {
+	s.data.Processing = false
+	s.data.SignatureFailure = fmt.Sprintf("%s %s signature %s verification failed: %v", s.round, scheme.String(), signature, err)
+	ctx.Log().WithError(err).WithFields(logan.F{
+		"round":     s.round,
+		"scheme":    scheme.String(),
+		"signature": signature,
+	}).Error("Produced signature verification failed")
 }
 
 // keySignatureController represents custom logic for types.SessionType_ReshareSession for signing the new key with old signature.
@@ -133,8 +221,9 @@ func (s *keySignatureController) Next() IController {
 }
 
 // finish will store the result signature and generates the ChangeParties operation to be signed in the next controller.
-func (s *keySignatureController) finish(signature string) {
+func (s *keySignatureController) finish(signature, eddsaSignature string) {
 	s.data.KeySignature = signature
+	s.data.EdDSAKeySignature = eddsaSignature
 	op := &rarimo.ChangeParties{
 		Parties:      s.data.NewParties,
 		NewPublicKey: s.data.NewSecret.GlobalPubKey(),
@@ -168,68 +257,94 @@ func (s *keySignatureController) updateSessionData(ctx core.Context) {
 	}
 }
 
-// rootSignatureController represents custom logic for both types.SessionType_ReshareSession
+// rootSignatureController represents common logic for both types.SessionType_ReshareSession
 // and types.SessionType_DefaultSession for signing the root of indexes set.
 type rootSignatureController struct {
 	data *LocalSessionData
 }
 
-// Implements iSignatureController interface
-var _ iSignatureController = &rootSignatureController{}
-
 // Next returns the finish controller instance.
 // WaitFor should be called before.
 func (s *rootSignatureController) Next() IController {
 	return s.data.GetFinishController()
 }
 
-// finish saves the generated signature
-func (s *rootSignatureController) finish(signature string) {
+// finish saves the generated signatures
+func (s *rootSignatureController) finish(signature, eddsaSignature string) {
 	s.data.OperationSignature = signature
+	s.data.EdDSAOperationSignature = eddsaSignature
+}
+
+// defaultRootSignatureController represents custom logic for types.SessionType_DefaultSession
+type defaultRootSignatureController struct {
+	rootSignatureController
 }
 
+// Implements iSignatureController interface
+var _ iSignatureController = &defaultRootSignatureController{}
+
 // updateSessionData updates the database entry according to the controller result.
-func (s *rootSignatureController) updateSessionData(ctx core.Context) {
-	switch s.data.SessionType {
-	case types.SessionType_DefaultSession:
-		data, err := ctx.PG().DefaultSessionDatumQ().DefaultSessionDatumByID(int64(s.data.SessionId), false)
-		if err != nil {
-			ctx.Log().WithError(err).Error("Error selecting session data")
-			return
-		}
+func (s *defaultRootSignatureController) updateSessionData(ctx core.Context) {
+	data, err := ctx.PG().DefaultSessionDatumQ().DefaultSessionDatumByID(int64(s.data.SessionId), false)
+	if err != nil {
+		ctx.Log().WithError(err).Error("Error selecting session data")
+		return
+	}
 
-		if data == nil {
-			ctx.Log().Error("Session data is not initialized")
-			return
-		}
+	if data == nil {
+		ctx.Log().Error("Session data is not initialized")
+		return
+	}
 
-		data.Signature = sql.NullString{
-			String: s.data.OperationSignature,
-			Valid:  s.data.OperationSignature != "",
-		}
+	data.Signature = sql.NullString{
+		String: s.data.OperationSignature,
+		Valid:  s.data.OperationSignature != "",
+	}
+	data.EddsaSignature = sql.NullString{
+		String: s.data.EdDSAOperationSignature,
+		Valid:  s.data.EdDSAOperationSignature != "",
+	}
 
-		err = ctx.PG().DefaultSessionDatumQ().Update(data)
-	case types.SessionType_ReshareSession:
-		data, err := ctx.PG().ReshareSessionDatumQ().ReshareSessionDatumByID(int64(s.data.SessionId), false)
-		if err != nil {
-			ctx.Log().WithError(err).Error("Error selecting session data")
-			return
-		}
+	if err = ctx.PG().DefaultSessionDatumQ().Update(data); err != nil {
+		ctx.Log().WithError(err).Error("Error updating session data entry")
+	}
+}
 
-		if data == nil {
-			ctx.Log().Error("Session data is not initialized")
-			return
-		}
+// reshareRootSignatureController represents custom logic for types.SessionType_ReshareSession
+type reshareRootSignatureController struct {
+	rootSignatureController
+}
 
-		data.KeySignature = sql.NullString{
-			String: s.data.KeySignature,
-			Valid:  s.data.KeySignature != "",
-		}
-		data.Root = sql.NullString{
-			String: s.data.Root,
-			Valid:  true,
-		}
+// Implements iSignatureController interface
+var _ iSignatureController = &reshareRootSignatureController{}
+
+// updateSessionData updates the database entry according to the controller result.
+func (s *reshareRootSignatureController) updateSessionData(ctx core.Context) {
+	data, err := ctx.PG().ReshareSessionDatumQ().ReshareSessionDatumByID(int64(s.data.SessionId), false)
+	if err != nil {
+		ctx.Log().WithError(err).Error("Error selecting session data")
+		return
+	}
+
+	if data == nil {
+		ctx.Log().Error("Session data is not initialized")
+		return
+	}
+
+	data.KeySignature = sql.NullString{
+		String: s.data.KeySignature,
+		Valid:  s.data.KeySignature != "",
+	}
+	data.EddsaKeySignature = sql.NullString{
+		String: s.data.EdDSAKeySignature,
+		Valid:  s.data.EdDSAKeySignature != "",
+	}
+	data.Root = sql.NullString{
+		String: s.data.Root,
+		Valid:  true,
+	}
 
-		err = ctx.PG().ReshareSessionDatumQ().Update(data)
+	if err = ctx.PG().ReshareSessionDatumQ().Update(data); err != nil {
+		ctx.Log().WithError(err).Error("Error updating session data entry")
 	}
 }
@@ -1,16 +1,14 @@
 package controllers
 
 import (
-	"sync"
+	"context"
 
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	eth "github.com/ethereum/go-ethereum/crypto"
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
-	"github.com/rarimo/tss-svc/internal/connectors"
 	"github.com/rarimo/tss-svc/internal/core"
 	"github.com/rarimo/tss-svc/internal/secret"
 	"github.com/rarimo/tss-svc/internal/tss"
 	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
 )
 
 // LocalSessionData represents all necessary data from current session to be shared between controllers.
@@ -18,6 +16,7 @@ type LocalSessionData struct {
 	SessionId          uint64
 	Processing         bool
 	SessionType        types.SessionType
+	Pipeline           *Pipeline
 	Proposer           rarimo.Party
 	Set                *core.InputSet
 	NewSecret          *secret.TssSecret
@@ -26,212 +25,134 @@ type LocalSessionData struct {
 	Acceptances        map[string]struct{}
 	OperationSignature string
 	KeySignature       string
-	NewParties         []*rarimo.Party
-	Offenders          map[string]struct{}
-	Signers            map[string]struct{}
-	IsSigner           bool
+	// EdDSAOperationSignature and EdDSAKeySignature are empty if EdDSA key is not generated or EdDSA signing failed
+	EdDSAOperationSignature string
+	EdDSAKeySignature       string
+	NewParties              []*rarimo.Party
+	// Generation is the resharing generation of the current key share agreed during reshare acceptance
+	Generation uint64
+	// EdDSAKey and EdDSAGeneration describe the EdDSA key share agreed during reshare acceptance.
+	// EdDSAKey is empty if EdDSA key should be generated from scratch.
+	EdDSAKey        string
+	EdDSAGeneration uint64
+	Offenders       map[string]struct{}
+	// Culprits contains the violations of the offenders blamed by the tss protocol
+	Culprits map[string]tss.Culprit
+	Signers  map[string]struct{}
+	IsSigner bool
+	// SignatureFailure describes the signing round and signature that failed verification
+	// or the failed EdDSA resharing (empty if none)
+	SignatureFailure string
+	// Restored is true if session data was restored from the snapshot after restart
+	Restored bool
+	// Observer is true if session is followed by the observer service that does not take part in the session
+	Observer bool
+	// Log is the session logger. It is not stored in the session type registry because
+	// sessions of the same type can run concurrently.
+	Log *logan.Entry
+	// base is the party context the session has been created with (see core.Context.Base)
+	base context.Context
 }
 
-func NewSessionData(ctx core.Context, id uint64, sessionType types.SessionType) *LocalSessionData {
+func NewSessionData(ctx core.Context, id uint64, sessionType types.SessionType, pipeline *Pipeline) *LocalSessionData {
 	set := core.NewInputSet(ctx.Client())
-	core.SetInRegistry(
-		core.ContextKeyBySessionType[sessionType],
-		core.LogKey,
-		ctx.Log().WithField("id", id).WithField("type", sessionType.String()),
-	)
 
 	return &LocalSessionData{
 		SessionType: sessionType,
+		Pipeline:    pipeline,
 		SessionId:   id,
 		Set:         set,
 		Acceptances: make(map[string]struct{}),
 		Proposer:    GetProposer(set.Parties, set.LastSignature, id),
 		Offenders:   make(map[string]struct{}),
+		Culprits:    make(map[string]tss.Culprit),
+		Observer:    ctx.Observer(),
+		Log:         ctx.Log().WithField("id", id).WithField("type", sessionType.String()),
+		base:        ctx.Base(),
 	}
 }
 
 func (data *LocalSessionData) Next() *LocalSessionData {
-	ctx := core.DefaultSessionContext(data.SessionType)
-	core.SetInRegistry(
-		core.ContextKeyBySessionType[data.SessionType],
-		core.LogKey,
-		ctx.Log().WithField("id", data.SessionId+1).WithField("type", data.SessionType.String()),
-	)
-
+	ctx := core.WrapCtx(core.GetSessionCtx(data.base, data.SessionType))
 	set := core.NewInputSet(ctx.Client())
 
 	return &LocalSessionData{
 		SessionType: data.SessionType,
+		Pipeline:    data.Pipeline,
 		SessionId:   data.SessionId + 1,
 		Set:         set,
 		Acceptances: make(map[string]struct{}),
 		Proposer:    GetProposer(set.Parties, set.LastSignature, data.SessionId+1),
 		Offenders:   make(map[string]struct{}),
+		Culprits:    make(map[string]tss.Culprit),
+		Observer:    data.Observer,
+		Log:         ctx.Log().WithField("id", data.SessionId+1).WithField("type", data.SessionType.String()),
+		base:        data.base,
 	}
+}
 
+// Context returns the session type context of the party the session belongs to with the session logger.
+func (data *LocalSessionData) Context() core.Context {
+	return core.WrapCtx(core.GetSessionCtx(core.WithLog(data.base, data.Log), data.SessionType))
 }
 
-// GetProposalController returns the proposal controller for the provided session data
-// For types.SessionType_DefaultSession the proposal controller will be based on current parties set (all active and inactive parties).
-// For types.SessionType_ReshareSession the proposal controller will be based on current parties set (all active and inactive parties).
-func (data *LocalSessionData) GetProposalController() IController {
-	ctx := core.DefaultSessionContext(data.SessionType)
-
-	switch data.SessionType {
-	case types.SessionType_DefaultSession:
-		return &ProposalController{
-			iProposalController: &defaultProposalController{
-				data:      data,
-				broadcast: connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Log()),
-			},
-			wg:   &sync.WaitGroup{},
-			data: data,
-			auth: core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
-		}
-
-	case types.SessionType_ReshareSession:
-		return &ProposalController{
-			iProposalController: &reshareProposalController{
-				data:      data,
-				broadcast: connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Log()),
-			},
-			wg:   &sync.WaitGroup{},
-			data: data,
-			auth: core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
-		}
+// addCulprits adds the parties blamed by the tss protocol to the offenders set.
+func (data *LocalSessionData) addCulprits(culprits []tss.Culprit) {
+	for _, culprit := range culprits {
+		data.Offenders[culprit.Account] = struct{}{}
+		data.Culprits[culprit.Account] = culprit
+	}
+}
+
+// blame adds the culprits of the tss party error to the offenders set.
+// If the error does not blame any party, the sender of the failed message is considered an offender.
+func (data *LocalSessionData) blame(sender *rarimo.Party, err error) {
+	ctx := data.Context()
+
+	culprits := tss.ErrorCulprits(err, ctx.SecretStorage().GetTssSecret().AccountAddress())
+	if len(culprits) == 0 {
+		data.Offenders[sender.Account] = struct{}{}
+		return
 	}
 
-	// Should not appear
-	panic("Invalid session type")
+	data.addCulprits(culprits)
+}
+
+// GetProposalController returns the proposal controller for the provided session data
+func (data *LocalSessionData) GetProposalController() IController {
+	return mustController(data.Pipeline.Proposal, data)
 }
 
 // GetAcceptanceController returns the acceptance controller for the provided session data
-// For types.SessionType_DefaultSession the acceptance controller will be based on current parties set (all active and inactive parties).
-// For types.SessionType_ReshareSession the acceptance controller will be based on current parties set (all active and inactive parties).
 func (data *LocalSessionData) GetAcceptanceController() IController {
-	ctx := core.DefaultSessionContext(data.SessionType)
-
-	switch data.SessionType {
-	case types.SessionType_DefaultSession:
-		return &AcceptanceController{
-			iAcceptanceController: &defaultAcceptanceController{
-				data:      data,
-				broadcast: connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Log()),
-			},
-			wg:   &sync.WaitGroup{},
-			data: data,
-			auth: core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
-		}
-	case types.SessionType_ReshareSession:
-		return &AcceptanceController{
-			iAcceptanceController: &reshareAcceptanceController{
-				data:      data,
-				broadcast: connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Log()),
-			},
-			wg:   &sync.WaitGroup{},
-			data: data,
-			auth: core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
-		}
-	}
-	// Should not appear
-	panic("Invalid session type")
+	return mustController(data.Pipeline.Acceptance, data)
 }
 
 // GetRootSignController returns the root signature controller based on the selected signers set.
 func (data *LocalSessionData) GetRootSignController() IController {
-	ctx := core.DefaultSessionContext(data.SessionType)
-
-	parties := getSignersList(data.Signers, data.Set.Parties)
-	return &SignatureController{
-		iSignatureController: &rootSignatureController{
-			data: data,
-		},
-		wg:    &sync.WaitGroup{},
-		data:  data,
-		auth:  core.NewRequestAuthorizer(parties, ctx.Log()),
-		party: tss.NewSignParty(data.Root, data.SessionId, data.SessionType, parties, ctx.SecretStorage().GetTssSecret(), ctx.Core(), ctx.Log()),
-	}
+	return mustController(data.Pipeline.RootSign, data)
 }
 
 // GetKeySignController returns the key signature controller based on the selected signers set.
 func (data *LocalSessionData) GetKeySignController() IController {
-	hash := hexutil.Encode(eth.Keccak256(hexutil.MustDecode(data.NewSecret.GlobalPubKey())))
-	ctx := core.DefaultSessionContext(data.SessionType)
-
-	parties := getSignersList(data.Signers, data.Set.Parties)
-	return &SignatureController{
-		iSignatureController: &keySignatureController{
-			data: data,
-		},
-		wg:    &sync.WaitGroup{},
-		data:  data,
-		auth:  core.NewRequestAuthorizer(parties, ctx.Log()),
-		party: tss.NewSignParty(hash, data.SessionId, data.SessionType, parties, ctx.SecretStorage().GetTssSecret(), ctx.Core(), ctx.Log()),
-	}
+	return mustController(data.Pipeline.KeySign, data)
 }
 
 // GetFinishController returns the finish controller for the provided session data
 func (data *LocalSessionData) GetFinishController() IController {
-	switch data.SessionType {
-	case types.SessionType_KeygenSession:
-		return &FinishController{
-			iFinishController: &keygenFinishController{
-				data: data,
-			},
-			wg:   &sync.WaitGroup{},
-			data: data,
-		}
-	case types.SessionType_ReshareSession:
-		return &FinishController{
-			iFinishController: &reshareFinishController{
-				data: data,
-			},
-			wg:   &sync.WaitGroup{},
-			data: data,
-		}
-	case types.SessionType_DefaultSession:
-		return &FinishController{
-			iFinishController: &defaultFinishController{
-				data: data,
-			},
-			wg:   &sync.WaitGroup{},
-			data: data,
-		}
-	}
-
-	// Should not appear
-	panic("Invalid session type")
+	return mustController(data.Pipeline.Finish, data)
 }
 
 // GetKeygenController returns the keygen controller for the provided session data
-// For types.SessionType_KeygenSession the keygen controller will be based on current parties set (all parties should be inactive).
-// For types.SessionType_ReshareSession the keygen controller will be based on current parties set (all active and inactive parties).
 func (data *LocalSessionData) GetKeygenController() IController {
-	ctx := core.DefaultSessionContext(data.SessionType)
-
-	switch data.SessionType {
-	case types.SessionType_ReshareSession:
-		return &KeygenController{
-			iKeygenController: &reshareKeygenController{
-				data: data,
-			},
-			wg:    &sync.WaitGroup{},
-			data:  data,
-			auth:  core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
-			party: tss.NewKeygenParty(data.SessionId, data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Core(), ctx.Log()),
-		}
-	case types.SessionType_KeygenSession:
-		return &KeygenController{
-			iKeygenController: &defaultKeygenController{
-				data: data,
-			},
-			wg:    &sync.WaitGroup{},
-			data:  data,
-			auth:  core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
-			party: tss.NewKeygenParty(data.SessionId, data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Core(), ctx.Log()),
-		}
+	return mustController(data.Pipeline.Keygen, data)
+}
+
+func mustController(factory ControllerFactory, data *LocalSessionData) IController {
+	if factory == nil {
+		// Should not appear
+		panic("Controller is not supported by session type " + data.SessionType.String())
 	}
 
-	// Should not appear
-	panic("Invalid session type")
+	return factory(data)
 }
@@ -12,6 +12,7 @@ var (
 	ErrUnsupportedContent  = goerr.New("unsupported content")
 	ErrInvalidRequestType  = goerr.New("invalid request type")
 	ErrSenderIsNotSigner   = goerr.New("sender is no a current signer or has not accepted the proposal")
+	ErrNoFreshPreParams    = goerr.New("pre-params pool is empty")
 )
 
 type (
@@ -27,4 +28,51 @@ type (
 		Next() IController
 		Type() types.ControllerType
 	}
+
+	// ICompletable is implemented by controllers that are able to detect that all expected messages have been received
+	// and no more actions are required. Such controllers can be finished before their bounds end.
+	ICompletable interface {
+		// Done returns true if controller has finished its logic and the session can switch to the next controller.
+		Done() bool
+	}
 )
+
+// RequestTypeByController defines the request type accepted by every controller type.
+// Requests of other types are not accepted by the controller.
+var RequestTypeByController = map[types.ControllerType]types.RequestType{
+	types.ControllerType_CONTROLLER_PROPOSAL:   types.RequestType_Proposal,
+	types.ControllerType_CONTROLLER_ACCEPTANCE: types.RequestType_Acceptance,
+	types.ControllerType_CONTROLLER_KEYGEN:     types.RequestType_Keygen,
+	types.ControllerType_CONTROLLER_SIGN:       types.RequestType_Sign,
+}
+
+// Accepts checks that request type corresponds to the controller type.
+// Echo requests are accepted by the controller that accepts the echoed request.
+func Accepts(controller IController, request *types.MsgSubmitRequest) bool {
+	requestType, ok := RequestTypeByController[controller.Type()]
+	return ok && echoedType(request) == requestType
+}
+
+// echoedType returns the type of the echoed request for echo requests and the request type otherwise.
+// Invalid echo requests are returned with echo type and will not be accepted by any controller.
+func echoedType(request *types.MsgSubmitRequest) types.RequestType {
+	if request.Data.Type != types.RequestType_Echo {
+		return request.Data.Type
+	}
+
+	echo := new(types.EchoRequest)
+	if err := request.Data.Details.UnmarshalTo(echo); err != nil || echo.Request == nil || echo.Request.Data == nil {
+		return types.RequestType_Echo
+	}
+
+	return echo.Request.Data.Type
+}
+
+// IsDone checks that controller supports early completion and has finished its logic.
+func IsDone(controller IController) bool {
+	if c, ok := controller.(ICompletable); ok {
+		return c.Done()
+	}
+
+	return false
+}
@@ -0,0 +1,302 @@
+package controllers
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/crypto"
+	"github.com/rarimo/tss-svc/internal/connectors"
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/internal/tss"
+	"github.com/rarimo/tss-svc/pkg/types"
+)
+
+// Signing round names used to record signature verification failures
+const (
+	keySignRound  = "key signing"
+	rootSignRound = "root signing"
+)
+
+// ControllerFactory creates the controller for the provided session data
+type ControllerFactory func(data *LocalSessionData) IController
+
+// Pipeline defines the session type specific controllers. Controllers select the next controller using
+// the pipeline of the session they are running in. Nil factory means that controller is not supported by the session type.
+type Pipeline struct {
+	Proposal   ControllerFactory
+	Acceptance ControllerFactory
+	Keygen     ControllerFactory
+	KeySign    ControllerFactory
+	RootSign   ControllerFactory
+	Finish     ControllerFactory
+}
+
+var (
+	// DefaultSessionPipeline defines the controllers of types.SessionType_DefaultSession:
+	// proposal -> acceptance -> root signature -> finish
+	DefaultSessionPipeline = &Pipeline{
+		Proposal:   newDefaultProposalController,
+		Acceptance: newDefaultAcceptanceController,
+		RootSign:   newDefaultRootSignController,
+		Finish:     newDefaultFinishController,
+	}
+
+	// ReshareSessionPipeline defines the controllers of types.SessionType_ReshareSession:
+	// proposal -> acceptance -> keygen -> key signature -> root signature -> finish
+	ReshareSessionPipeline = &Pipeline{
+		Proposal:   newReshareProposalController,
+		Acceptance: newReshareAcceptanceController,
+		Keygen:     newReshareKeygenController,
+		KeySign:    newKeySignController,
+		RootSign:   newReshareRootSignController,
+		Finish:     newReshareFinishController,
+	}
+
+	// KeygenSessionPipeline defines the controllers of types.SessionType_KeygenSession:
+	// keygen -> finish
+	KeygenSessionPipeline = &Pipeline{
+		Keygen: newDefaultKeygenController,
+		Finish: newKeygenFinishController,
+	}
+)
+
+// newDefaultProposalController returns the proposal controller based on current parties set (all active and inactive parties).
+func newDefaultProposalController(data *LocalSessionData) IController {
+	ctx := data.Context()
+
+	return &ProposalController{
+		iProposalController: &defaultProposalController{
+			data:      data,
+			broadcast: connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Log()),
+		},
+		wg:   &sync.WaitGroup{},
+		data: data,
+		auth: core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
+	}
+}
+
+// newReshareProposalController returns the proposal controller based on current parties set (all active and inactive parties).
+func newReshareProposalController(data *LocalSessionData) IController {
+	ctx := data.Context()
+
+	return &ProposalController{
+		iProposalController: &reshareProposalController{
+			data:      data,
+			broadcast: connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Log()),
+		},
+		wg:   &sync.WaitGroup{},
+		data: data,
+		auth: core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
+	}
+}
+
+// newDefaultAcceptanceController returns the acceptance controller based on current parties set (all active and inactive parties).
+func newDefaultAcceptanceController(data *LocalSessionData) IController {
+	ctx := data.Context()
+
+	return &AcceptanceController{
+		iAcceptanceController: &defaultAcceptanceController{
+			data:      data,
+			broadcast: connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Log()),
+		},
+		wg:   &sync.WaitGroup{},
+		data: data,
+		auth: core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
+	}
+}
+
+// newReshareAcceptanceController returns the acceptance controller based on current parties set (all active and inactive parties).
+func newReshareAcceptanceController(data *LocalSessionData) IController {
+	ctx := data.Context()
+
+	// Key share holders use own generation, others take it from the verified parties acceptances
+	secret := ctx.SecretStorage().GetTssSecret()
+
+	eddsaShares := make(map[string]eddsaShare)
+	if secret.GlobalPubKey() != "" {
+		eddsaShares[secret.AccountAddress()] = eddsaShare{key: secret.GlobalEdDSAPubKey(), generation: secret.EdDSAGeneration()}
+	}
+
+	return &AcceptanceController{
+		iAcceptanceController: &reshareAcceptanceController{
+			data:          data,
+			broadcast:     connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, secret, ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Log()),
+			generation:    secret.Generation(),
+			generationSet: secret.GlobalPubKey() != "",
+			eddsaShares:   eddsaShares,
+		},
+		wg:   &sync.WaitGroup{},
+		data: data,
+		auth: core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
+	}
+}
+
+// newDefaultRootSignController returns the root signature controller based on the selected signers set.
+// Root is also signed with EdDSA key if it has been generated.
+func newDefaultRootSignController(data *LocalSessionData) IController {
+	ctx := data.Context()
+
+	var eddsaRoot string
+	if ctx.SecretStorage().GetTssSecret().GlobalEdDSAPubKey() != "" {
+		eddsaRoot = data.Root
+	}
+
+	return newSignatureController(data, rootSignRound, data.Root, eddsaRoot, &defaultRootSignatureController{
+		rootSignatureController: rootSignatureController{data: data},
+	})
+}
+
+// newReshareRootSignController returns the root signature controller based on the selected signers set.
+func newReshareRootSignController(data *LocalSessionData) IController {
+	return newSignatureController(data, rootSignRound, data.Root, "", &reshareRootSignatureController{
+		rootSignatureController: rootSignatureController{data: data},
+	})
+}
+
+// newKeySignController returns the key signature controller based on the selected signers set.
+// New EdDSA key is signed by the old EdDSA key if both of them exist.
+func newKeySignController(data *LocalSessionData) IController {
+	ctx := data.Context()
+
+	hash := hexutil.Encode(eth.Keccak256(hexutil.MustDecode(data.NewSecret.GlobalPubKey())))
+
+	var eddsaHash string
+	if ctx.SecretStorage().GetTssSecret().GlobalEdDSAPubKey() != "" && data.NewSecret.GlobalEdDSAPubKey() != "" {
+		eddsaHash = hexutil.Encode(eth.Keccak256(hexutil.MustDecode(data.NewSecret.GlobalEdDSAPubKey())))
+	}
+
+	return newSignatureController(data, keySignRound, hash, eddsaHash, &keySignatureController{data: data})
+}
+
+// newSignatureController returns the signature controller for the provided data.
+// EdDSA signing is disabled if eddsaToSign is empty.
+func newSignatureController(data *LocalSessionData, round, toSign, eddsaToSign string, controller iSignatureController) IController {
+	ctx := data.Context()
+
+	parties := getSignersList(data.Signers, data.Set.Parties)
+	c := &SignatureController{
+		iSignatureController: controller,
+		wg:                   &sync.WaitGroup{},
+		data:                 data,
+		auth:                 core.NewRequestAuthorizer(parties, ctx.Log()),
+		party:                tss.NewSignParty(toSign, data.SessionId, data.SessionType, types.KeyScheme_ECDSA, parties, ctx.SecretStorage().GetTssSecret(), ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Core(), ctx.Log()),
+		round:                round,
+		eddsaPubKey:          ctx.SecretStorage().GetTssSecret().GlobalEdDSAPubKey(),
+	}
+
+	if eddsaToSign != "" {
+		c.eddsaParty = tss.NewSignParty(eddsaToSign, data.SessionId, data.SessionType, types.KeyScheme_EdDSA, parties, ctx.SecretStorage().GetTssSecret(), ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Core(), ctx.Log())
+	}
+
+	return c
+}
+
+// newDefaultKeygenController returns the keygen controller based on current parties set (all parties should be inactive).
+// Party without fresh pre-params generates them during the key generation (see takeFreshPreParams).
+func newDefaultKeygenController(data *LocalSessionData) IController {
+	ctx := data.Context()
+	fresh := takeFreshPreParams(ctx)
+
+	party := tss.NewKeygenParty(data.SessionId, data.SessionType, types.KeyScheme_ECDSA, data.Set.Parties, fresh, ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Core(), ctx.Log())
+	eddsaParty := tss.NewKeygenParty(data.SessionId, data.SessionType, types.KeyScheme_EdDSA, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Core(), ctx.Log())
+	return newKeygenController(data, party, eddsaParty, &defaultKeygenController{data: data})
+}
+
+// newReshareKeygenController returns the keygen controller that reshares the current key from the selected signers set
+// to the current parties set (all active and inactive parties). EdDSA key is reshared the same way if all active parties
+// hold the same EdDSA key share, otherwise it is generated from scratch.
+// Party refuses the reshare acceptance without fresh pre-params (see reshareAcceptanceController.shareAcceptance),
+// but the pool can still be emptied in between: pre-params are generated during the resharing in such case.
+func newReshareKeygenController(data *LocalSessionData) IController {
+	ctx := data.Context()
+	fresh := takeFreshPreParams(ctx)
+
+	party := tss.NewReshareParty(
+		data.SessionId,
+		data.SessionType,
+		types.KeyScheme_ECDSA,
+		getSignersList(data.Signers, data.Set.Parties),
+		data.Set.Parties,
+		data.Set.T,
+		data.Generation,
+		fresh,
+		ctx.Transport(),
+		ctx.Timer(),
+		ctx.Broadcast(),
+		ctx.Core(),
+		ctx.Log(),
+	)
+
+	if data.EdDSAKey == "" {
+		eddsaParty := tss.NewKeygenParty(data.SessionId, data.SessionType, types.KeyScheme_EdDSA, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Core(), ctx.Log())
+		return newKeygenController(data, party, eddsaParty, &reshareKeygenController{data: data})
+	}
+
+	eddsaParty := tss.NewReshareParty(
+		data.SessionId,
+		data.SessionType,
+		types.KeyScheme_EdDSA,
+		getSignersList(data.Signers, data.Set.Parties),
+		data.Set.Parties,
+		data.Set.T,
+		data.EdDSAGeneration,
+		ctx.SecretStorage().GetTssSecret(),
+		ctx.Transport(),
+		ctx.Timer(),
+		ctx.Broadcast(),
+		ctx.Core(),
+		ctx.Log(),
+	)
+
+	return newKeygenController(data, party, eddsaParty, &reshareKeygenController{data: data})
+}
+
+// takeFreshPreParams returns the current secret with the fresh pre-params from the pool that will be used
+// for the new key share. Current pre-params are never reused, so if the pool is empty the returned secret has
+// no pre-params and the tss party generates them in round. It can take a long time, so the party can miss
+// the session deadline, but it still takes part in the session.
+func takeFreshPreParams(ctx core.Context) *secret.TssSecret {
+	params, err := ctx.SecretStorage().TakePreParams()
+	switch {
+	case err != nil:
+		ctx.Log().WithError(err).Error("Failed to take pre-params from the pool, pre-params will be generated in round")
+	case params == nil:
+		ctx.Log().WithError(ErrNoFreshPreParams).Warn("Pre-params will be generated in round")
+	}
+
+	return ctx.SecretStorage().GetTssSecret().NewWithPreParams(params)
+}
+
+func newKeygenController(data *LocalSessionData, party, eddsaParty keygenParty, controller iKeygenController) IController {
+	ctx := data.Context()
+
+	return &KeygenController{
+		iKeygenController: controller,
+		wg:                &sync.WaitGroup{},
+		data:              data,
+		auth:              core.NewRequestAuthorizer(data.Set.Parties, ctx.Log()),
+		party:             party,
+		eddsaParty:        eddsaParty,
+	}
+}
+
+func newDefaultFinishController(data *LocalSessionData) IController {
+	return newFinishController(data, &defaultFinishController{data: data})
+}
+
+func newReshareFinishController(data *LocalSessionData) IController {
+	return newFinishController(data, &reshareFinishController{data: data})
+}
+
+func newKeygenFinishController(data *LocalSessionData) IController {
+	return newFinishController(data, &keygenFinishController{data: data})
+}
+
+func newFinishController(data *LocalSessionData, controller iFinishController) IController {
+	return &FinishController{
+		iFinishController: controller,
+		wg:                &sync.WaitGroup{},
+		data:              data,
+	}
+}
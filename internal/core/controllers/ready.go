@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	goerr "errors"
+
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/connectors"
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/timer"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// ReadyDelayBlocks defines the amount of blocks between the last ready signal and the early controller finish.
+	// Ready signal is accepted only if it has been received not later than ReadyDelayBlocks after the claimed height,
+	// so all accepted signals are received before the finish block.
+	ReadyDelayBlocks = 2
+	// ReadyClockSkewBlocks defines the amount of blocks the ready signal height can be ahead of the local timer,
+	// because parties observe the new blocks at slightly different moments.
+	ReadyClockSkewBlocks = 1
+)
+
+var (
+	ErrInvalidReadySignal = goerr.New("ready signal is out of the session bounds")
+	// ErrUntimelyReadySignal is returned if the ready signal height does not correspond to the local timer
+	ErrUntimelyReadySignal = goerr.New("ready signal height does not correspond to the current block")
+)
+
+type readyKey struct {
+	controller types.ControllerType
+	start      uint64
+}
+
+// ReadyTracker collects the ready signals of the parties for the session controllers. A controller is identified by
+// its type and start block, so all parties that agree on the session bounds signal for the same controller.
+// The controller is finished early on the same block by all parties: ReadyDelayBlocks after the maximum height of
+// the ready signals received from all expected parties (see ReadyParties).
+// Claimed heights are bounded by the local timer, so the late or lying party can not move the finish block:
+// untimely signals are refused and the controller runs until its timeline end if any expected signal is missing.
+// ReadyTracker is not thread-safe and should be used under the session lock.
+type ReadyTracker struct {
+	data      *LocalSessionData
+	broadcast *connectors.BroadcastConnector
+	timer     *timer.Timer
+	start     uint64
+	end       uint64
+	signals   map[readyKey]map[string]uint64
+}
+
+func NewReadyTracker(data *LocalSessionData, bounds *core.BoundsManager) *ReadyTracker {
+	ctx := data.Context()
+
+	return &ReadyTracker{
+		data:      data,
+		broadcast: connectors.NewBroadcastConnector(data.SessionType, data.Set.Parties, ctx.SecretStorage().GetTssSecret(), ctx.Transport(), ctx.Timer(), ctx.Broadcast(), ctx.Log()),
+		timer:     ctx.Timer(),
+		start:     bounds.SessionStart,
+		end:       bounds.SessionEnd,
+		signals:   make(map[readyKey]map[string]uint64),
+	}
+}
+
+// Receive stores the ready signal of the authorized sender. Only the first signal of the sender is stored.
+// Signal is refused if its height is ahead of the local timer (more than ReadyClockSkewBlocks) or it has been
+// received later than ReadyDelayBlocks after its height.
+func (r *ReadyTracker) Receive(sender *rarimo.Party, request *types.MsgSubmitRequest) error {
+	ready := new(types.ReadyRequest)
+	if err := request.Data.Details.UnmarshalTo(ready); err != nil {
+		return errors.Wrap(err, "error parsing details")
+	}
+
+	if ready.Start < r.start || ready.Start > r.end || ready.Height < ready.Start {
+		return ErrInvalidReadySignal
+	}
+
+	current := r.timer.CurrentBlock()
+	if ready.Height > current+ReadyClockSkewBlocks || current > ready.Height+ReadyDelayBlocks {
+		return errors.Wrap(ErrUntimelyReadySignal, "ready signal refused", logan.F{
+			"sender":  sender.Account,
+			"height":  ready.Height,
+			"current": current,
+		})
+	}
+
+	r.add(readyKey{controller: ready.Controller, start: ready.Start}, sender.Account, ready.Height)
+	return nil
+}
+
+// Signal shares the self ready signal for the controller once. Observer does not take part in the session,
+// so it only follows the signals of other parties.
+func (r *ReadyTracker) Signal(controller types.ControllerType, start, height uint64) {
+	if r.data.Observer {
+		return
+	}
+
+	ctx := r.data.Context()
+	key := readyKey{controller: controller, start: start}
+	self := ctx.SecretStorage().GetTssSecret().AccountAddress()
+
+	if _, ok := r.signals[key][self]; ok {
+		return
+	}
+
+	r.add(key, self, height)
+
+	details, err := anypb.New(&types.ReadyRequest{Controller: controller, Start: start, Height: height})
+	if err != nil {
+		ctx.Log().WithError(err).Error("Error parsing details")
+		return
+	}
+
+	ctx.Log().Infof("Controller %s started on block %d is ready on block %d", controller.String(), start, height)
+
+	r.broadcast.SubmitAllWithReportAsync(ctx.Context(), ctx.Core(), &types.MsgSubmitRequest{
+		Data: &types.RequestData{
+			Type:        types.RequestType_Ready,
+			Id:          r.data.SessionId,
+			IsBroadcast: true,
+			Details:     details,
+		},
+	})
+}
+
+// Finish returns the block the controller should be finished on if all expected parties have signaled ready.
+// All accepted signals are received before the returned block (see Receive), so parties that have accepted the same
+// signals finish on the same block. Otherwise, the controller is finished on its timeline end.
+func (r *ReadyTracker) Finish(controller types.ControllerType, start uint64) (uint64, bool) {
+	signals := r.signals[readyKey{controller: controller, start: start}]
+	expected := ReadyParties(r.data, controller)
+	if len(expected) == 0 {
+		return 0, false
+	}
+
+	var last uint64
+	for account := range expected {
+		height, ok := signals[account]
+		if !ok {
+			return 0, false
+		}
+
+		if height > last {
+			last = height
+		}
+	}
+
+	return last + ReadyDelayBlocks, true
+}
+
+func (r *ReadyTracker) add(key readyKey, account string, height uint64) {
+	if _, ok := r.signals[key]; !ok {
+		r.signals[key] = make(map[string]uint64)
+	}
+
+	if _, ok := r.signals[key][account]; !ok {
+		r.signals[key][account] = height
+	}
+}
+
+// ReadyParties returns the accounts of the parties that should signal ready to finish the controller early:
+// current signers for the signature controllers and all session parties for other controllers.
+func ReadyParties(data *LocalSessionData, controller types.ControllerType) map[string]struct{} {
+	if controller == types.ControllerType_CONTROLLER_SIGN {
+		return data.Signers
+	}
+
+	parties := make(map[string]struct{}, len(data.Set.Parties))
+	for _, party := range data.Set.Parties {
+		parties[party.Account] = struct{}{}
+	}
+
+	return parties
+}
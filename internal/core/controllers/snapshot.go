@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/data"
+	"github.com/rarimo/tss-svc/internal/tss"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// RestoredSession contains the session data restored from the snapshot and the controller to resume the session from.
+type RestoredSession struct {
+	Data            *LocalSessionData
+	Controller      IController
+	SessionStart    uint64
+	ControllerStart uint64
+}
+
+// SaveSnapshot stores the session data that is required to resume the session after restart
+// from the controller of provided type that starts on the provided block.
+func SaveSnapshot(ctx core.Context, session *LocalSessionData, controller types.ControllerType, sessionStart, controllerStart uint64) error {
+	culprits, err := culpritsToJSON(session.Culprits)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal culprits")
+	}
+
+	snapshot := &data.SessionSnapshot{
+		SessionType:     int(session.SessionType),
+		SessionID:       int64(session.SessionId),
+		Controller:      int(controller),
+		BeginBlock:      int64(sessionStart),
+		ControllerBlock: int64(controllerStart),
+		Processing:      session.Processing,
+		Proposer: sql.NullString{
+			String: session.Proposer.Account,
+			Valid:  session.Proposer.Account != "",
+		},
+		Indexes: session.Indexes,
+		Root: sql.NullString{
+			String: session.Root,
+			Valid:  session.Root != "",
+		},
+		Acceptances: acceptancesToArr(session.Acceptances),
+		Signers:     acceptancesToArr(session.Signers),
+		Offenders:   acceptancesToArr(session.Offenders),
+		IsSigner:    session.IsSigner,
+		OperationSignature: sql.NullString{
+			String: session.OperationSignature,
+			Valid:  session.OperationSignature != "",
+		},
+		KeySignature: sql.NullString{
+			String: session.KeySignature,
+			Valid:  session.KeySignature != "",
+		},
+		EddsaOperationSignature: sql.NullString{
+			String: session.EdDSAOperationSignature,
+			Valid:  session.EdDSAOperationSignature != "",
+		},
+		EddsaKeySignature: sql.NullString{
+			String: session.EdDSAKeySignature,
+			Valid:  session.EdDSAKeySignature != "",
+		},
+		Culprits: culprits,
+	}
+
+	if snapshot.Indexes == nil {
+		snapshot.Indexes = []string{}
+	}
+
+	return errors.Wrap(ctx.PG().SessionSnapshotQ().Upsert(snapshot), "failed to store session snapshot")
+}
+
+// DeleteSnapshot removes the snapshot of the finished session.
+func DeleteSnapshot(ctx core.Context, sessionType types.SessionType, id uint64) error {
+	snapshot := &data.SessionSnapshot{
+		SessionType: int(sessionType),
+		SessionID:   int64(id),
+	}
+
+	return errors.Wrap(ctx.PG().SessionSnapshotQ().Delete(snapshot), "failed to delete session snapshot")
+}
+
+// RestoreSession loads the snapshot of the session with provided id and restores the session data.
+// Returns nil if there is no snapshot for the session or the snapshot can not be resumed.
+// Snapshots stored before the keygen or signing controllers are stale after restart: TSS parties state is not
+// stored, so the party can not rejoin the protocol. Such snapshots are removed and the session is skipped,
+// other parties will finish it without this party.
+func RestoreSession(ctx core.Context, id uint64, sessionType types.SessionType, pipeline *Pipeline) (*RestoredSession, error) {
+	snapshot, err := ctx.PG().SessionSnapshotQ().SessionSnapshotBySessionTypeSessionID(int(sessionType), int64(id), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select session snapshot")
+	}
+
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	controllerType := types.ControllerType(snapshot.Controller)
+	if !resumable(controllerType) {
+		ctx.Log().Infof("Session %s #%d snapshot on controller %s can not be resumed, removing it", sessionType.String(), id, controllerType.String())
+		return nil, DeleteSnapshot(ctx, sessionType, id)
+	}
+
+	culprits, err := jsonToCulprits(snapshot.Culprits)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal culprits")
+	}
+
+	set := core.NewInputSet(ctx.Client())
+
+	session := &LocalSessionData{
+		SessionType:             sessionType,
+		Pipeline:                pipeline,
+		SessionId:               id,
+		Processing:              snapshot.Processing,
+		Set:                     set,
+		Indexes:                 snapshot.Indexes,
+		Root:                    snapshot.Root.String,
+		Acceptances:             arrToSet(snapshot.Acceptances),
+		Signers:                 arrToSet(snapshot.Signers),
+		Offenders:               arrToSet(snapshot.Offenders),
+		Culprits:                culprits,
+		IsSigner:                snapshot.IsSigner,
+		OperationSignature:      snapshot.OperationSignature.String,
+		KeySignature:            snapshot.KeySignature.String,
+		EdDSAOperationSignature: snapshot.EddsaOperationSignature.String,
+		EdDSAKeySignature:       snapshot.EddsaKeySignature.String,
+		Proposer:                GetProposer(set.Parties, set.LastSignature, id),
+		Restored:                true,
+		Observer:                ctx.Observer(),
+		Log:                     ctx.Log().WithField("id", id).WithField("type", sessionType.String()),
+		base:                    ctx.Base(),
+	}
+
+	// Indexes of the restored session are still being signed
+	if session.Processing {
+		ctx.Pool().Reserve(id, session.Indexes)
+	}
+
+	if proposer, ok := findParty(set.Parties, snapshot.Proposer.String); ok {
+		session.Proposer = *proposer
+	}
+
+	restored := &RestoredSession{
+		Data:            session,
+		Controller:      session.restoreController(controllerType),
+		SessionStart:    uint64(snapshot.BeginBlock),
+		ControllerStart: uint64(snapshot.ControllerBlock),
+	}
+
+	ctx.Log().WithFields(logan.F{
+		"snapshot_controller": controllerType.String(),
+		"restored_controller": restored.Controller.Type().String(),
+	}).Infof("Session %s #%d restored from snapshot", sessionType.String(), id)
+
+	return restored, nil
+}
+
+// resumable checks if the session can be resumed from the controller of provided type.
+func resumable(t types.ControllerType) bool {
+	return t == types.ControllerType_CONTROLLER_ACCEPTANCE || t == types.ControllerType_CONTROLLER_FINISH
+}
+
+// restoreController returns the controller to resume the session from (see resumable).
+func (data *LocalSessionData) restoreController(t types.ControllerType) IController {
+	if t == types.ControllerType_CONTROLLER_ACCEPTANCE {
+		return data.GetAcceptanceController()
+	}
+
+	// Generated key is not stored in snapshots, so the session is finished as unsuccessful
+	if data.Pipeline.Keygen != nil {
+		data.Processing = false
+	}
+
+	return data.GetFinishController()
+}
+
+// culpritsToJSON encodes the culprits as JSON array. Returns null string if there are no culprits.
+func culpritsToJSON(culprits map[string]tss.Culprit) (sql.NullString, error) {
+	if len(culprits) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	list := make([]tss.Culprit, 0, len(culprits))
+	for _, culprit := range culprits {
+		list = append(list, culprit)
+	}
+
+	raw, err := json.Marshal(list)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(raw), Valid: true}, nil
+}
+
+// jsonToCulprits decodes the culprits stored by culpritsToJSON.
+func jsonToCulprits(raw sql.NullString) (map[string]tss.Culprit, error) {
+	culprits := make(map[string]tss.Culprit)
+	if !raw.Valid || raw.String == "" {
+		return culprits, nil
+	}
+
+	var list []tss.Culprit
+	if err := json.Unmarshal([]byte(raw.String), &list); err != nil {
+		return nil, err
+	}
+
+	for _, culprit := range list {
+		culprits[culprit.Account] = culprit
+	}
+
+	return culprits, nil
+}
+
+func arrToSet(list []string) map[string]struct{} {
+	res := make(map[string]struct{}, len(list))
+	for _, v := range list {
+		res[v] = struct{}{}
+	}
+	return res
+}
+
+func findParty(parties []*rarimo.Party, account string) (*rarimo.Party, bool) {
+	for _, party := range parties {
+		if party.Account == account {
+			return party, true
+		}
+	}
+
+	return nil, false
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"net"
 
 	"github.com/rarimo/tss-svc/internal/config"
@@ -12,7 +13,6 @@ import (
 	"github.com/rarimo/tss-svc/pkg/types"
 	"github.com/tendermint/tendermint/rpc/client/http"
 	"gitlab.com/distributed_lab/logan/v3"
-	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
 
@@ -49,17 +49,13 @@ const (
 	TendermintKey
 	ListenerKey
 	SwaggerKey
+	TimelineScheduleKey
+	ObserverKey
+	BroadcastKey
+	TransportKey
 )
 
-var (
-	registries = make(map[ContextKey]*registry)
-
-	ContextKeyBySessionType = map[types.SessionType]ContextKey{
-		types.SessionType_DefaultSession: DefaultSessionContextKey,
-		types.SessionType_ReshareSession: ReshareSessionContextKey,
-		types.SessionType_KeygenSession:  KeygenSessionContextKey,
-	}
-)
+var registries = make(map[ContextKey]*registry)
 
 func SetInRegistry(ctxKey ContextKey, key RegistryKey, value any) {
 	rg := registries[ctxKey]
@@ -73,49 +69,68 @@ func SetInRegistry(ctxKey ContextKey, key RegistryKey, value any) {
 	rg.registry[key] = value
 }
 
-func Initialize(cfg config.Config) {
-	db := pg.New(cfg.DB())
-	SetInRegistry(GlobalContextKey, PGKey, db)
-	SetInRegistry(DefaultSessionContextKey, PGKey, db)
-	SetInRegistry(ReshareSessionContextKey, PGKey, db)
-	SetInRegistry(KeygenSessionContextKey, PGKey, db)
+// SetInSessionRegistries sets the value to the global registry and registries of all registered session types
+func SetInSessionRegistries(key RegistryKey, value any) {
+	SetInRegistry(GlobalContextKey, key, value)
+	for _, def := range SessionDefinitions() {
+		SetInRegistry(def.ContextKey, key, value)
+	}
+}
 
+// Initialize fills the registries of global and all registered session types contexts.
+// Provided transport is used by default to deliver requests to other parties (see WithTransport).
+// All session types should be registered before.
+func Initialize(cfg config.Config, transport connectors.Transport) {
 	secret := secret.NewVaultStorage(cfg)
-	SetInRegistry(GlobalContextKey, SecretKey, secret)
-	SetInRegistry(DefaultSessionContextKey, SecretKey, secret)
-	SetInRegistry(ReshareSessionContextKey, SecretKey, secret)
-	SetInRegistry(KeygenSessionContextKey, SecretKey, secret)
-
-	SetInRegistry(GlobalContextKey, ClientKey, cfg.Cosmos())
-	SetInRegistry(DefaultSessionContextKey, ClientKey, cfg.Cosmos())
-	SetInRegistry(ReshareSessionContextKey, ClientKey, cfg.Cosmos())
-	SetInRegistry(KeygenSessionContextKey, ClientKey, cfg.Cosmos())
-
-	core := connectors.NewCoreConnector(cfg.Cosmos(), secret.GetTssSecret(), cfg.Log(), cfg.ChainParams())
-	SetInRegistry(GlobalContextKey, CoreKey, core)
-	SetInRegistry(DefaultSessionContextKey, CoreKey, core)
-	SetInRegistry(ReshareSessionContextKey, CoreKey, core)
-	SetInRegistry(KeygenSessionContextKey, CoreKey, core)
-
-	pool := pool.NewPool(cfg)
-	SetInRegistry(GlobalContextKey, PoolKey, pool)
-	SetInRegistry(DefaultSessionContextKey, PoolKey, pool)
+	initialize(cfg, secret, connectors.NewCoreConnector(cfg.Cosmos(), secret.GetTssSecret(), cfg.Log(), cfg.ChainParams()), transport)
+	SetInSessionRegistries(ObserverKey, false)
+}
+
+// InitializeObserver fills the registries for the observer service that does not hold any secret data
+// and never submits transactions to the core.
+// All session types should be registered before.
+func InitializeObserver(cfg config.Config, transport connectors.Transport) {
+	secret := secret.NewObserverStorage()
+	initialize(cfg, secret, connectors.NewReadOnlyCoreConnector(cfg.Cosmos(), secret.GetTssSecret(), cfg.Log(), cfg.ChainParams()), transport)
+	SetInSessionRegistries(ObserverKey, true)
+}
+
+func initialize(cfg config.Config, secret secret.Storage, core *connectors.CoreConnector, transport connectors.Transport) {
+	SetInSessionRegistries(PGKey, pg.New(cfg.DB()))
+
+	SetInSessionRegistries(SecretKey, secret)
+
+	SetInSessionRegistries(ClientKey, cfg.Cosmos())
+
+	SetInSessionRegistries(CoreKey, core)
+
+	SetInSessionRegistries(PoolKey, pool.NewPool(cfg))
 
 	timer := timer.NewTimer(cfg.Tendermint(), cfg.Log())
-	SetInRegistry(GlobalContextKey, TimerKey, timer)
+	SetInSessionRegistries(TimerKey, timer)
 
 	SetInRegistry(GlobalContextKey, TendermintKey, cfg.Tendermint())
 
-	SetInRegistry(GlobalContextKey, LogKey, cfg.Log())
+	SetInSessionRegistries(LogKey, cfg.Log())
 
 	SetInRegistry(GlobalContextKey, ListenerKey, cfg.Listener())
 
-	SetInRegistry(GlobalContextKey, SwaggerKey, cfg.Swagger())
-	SetInRegistry(DefaultSessionContextKey, SwaggerKey, cfg.Swagger())
-	SetInRegistry(ReshareSessionContextKey, SwaggerKey, cfg.Swagger())
-	SetInRegistry(KeygenSessionContextKey, SwaggerKey, cfg.Swagger())
+	SetInSessionRegistries(SwaggerKey, cfg.Swagger())
+
+	SetInSessionRegistries(BroadcastKey, cfg.Broadcast())
+
+	SetInSessionRegistries(TransportKey, transport)
+
+	schedule, err := NewTimelineSchedule(cfg.Session())
+	if err != nil {
+		panic(err)
+	}
+
+	SetInSessionRegistries(TimelineScheduleKey, schedule)
 }
 
+// WrapCtx fills the context with the registry values. Values that are already set in the context
+// (see WithLog) are not overridden.
 func WrapCtx(ctx context.Context) Context {
 	st := ctx.Value(ContextTypeKey).(ContextKey)
 	rg := registries[st]
@@ -124,14 +139,34 @@ func WrapCtx(ctx context.Context) Context {
 	}
 
 	for k, v := range rg.registry {
-		ctx = context.WithValue(ctx, k, v)
+		if ctx.Value(k) == nil {
+			ctx = context.WithValue(ctx, k, v)
+		}
 	}
 
 	return Context{ctx: ctx}
 }
 
+// WithLog returns the context with the provided logger that is used instead of the registry one.
+// Sessions of the same type can run concurrently, so every session carries its own logger.
+func WithLog(ctx context.Context, log *logan.Entry) context.Context {
+	return context.WithValue(ctx, LogKey, log)
+}
+
+// WithTransport returns the party context with the provided transport that is used instead of the registry one.
+// Sessions created with such context deliver all their requests with the provided transport (see Context.Base),
+// so several parties can run in one binary over connectors.InMemoryTransport.
+func WithTransport(ctx context.Context, transport connectors.Transport) context.Context {
+	return context.WithValue(ctx, TransportKey, transport)
+}
+
+// SessionContextKey returns the context key of registered session type
+func SessionContextKey(sessionType types.SessionType) ContextKey {
+	return MustGetSessionDefinition(sessionType).ContextKey
+}
+
 func GetSessionCtx(ctx context.Context, sessionType types.SessionType) context.Context {
-	return addCtxTypeKey(ctx, ContextKeyBySessionType[sessionType])
+	return addCtxTypeKey(ctx, SessionContextKey(sessionType))
 }
 
 func DefaultSessionContext(sessionType types.SessionType) Context {
@@ -146,6 +181,12 @@ func (c *Context) Context() context.Context {
 	return c.ctx
 }
 
+// Base returns the context values without the context cancellation. Sessions use it as the base for the contexts
+// of their controllers, so the party values (see WithTransport) are kept during the whole sessions chain.
+func (c *Context) Base() context.Context {
+	return context.WithoutCancel(c.ctx)
+}
+
 func (c *Context) PG() *pg.Storage {
 	return c.ctx.Value(PGKey).(*pg.Storage)
 }
@@ -166,6 +207,12 @@ func (c *Context) Core() *connectors.CoreConnector {
 	return c.ctx.Value(CoreKey).(*connectors.CoreConnector)
 }
 
+// Observer returns true if service is running in observer mode: it never shares data with parties,
+// does not take part in TSS rounds and does not submit transactions to the core.
+func (c *Context) Observer() bool {
+	return c.ctx.Value(ObserverKey).(bool)
+}
+
 func (c *Context) Pool() *pool.Pool {
 	return c.ctx.Value(PoolKey).(*pool.Pool)
 }
@@ -186,6 +233,24 @@ func (c *Context) Swagger() *config.SwaggerInfo {
 	return c.ctx.Value(SwaggerKey).(*config.SwaggerInfo)
 }
 
+func (c *Context) Broadcast() *config.BroadcastInfo {
+	return c.ctx.Value(BroadcastKey).(*config.BroadcastInfo)
+}
+
+// Transport returns the transport used to deliver requests to other parties (gRPC by default).
+func (c *Context) Transport() connectors.Transport {
+	return c.ctx.Value(TransportKey).(connectors.Transport)
+}
+
+func (c *Context) TimelineVersions(sessionType types.SessionType) TimelineVersions {
+	return c.ctx.Value(TimelineScheduleKey).(TimelineSchedule)[sessionType]
+}
+
+// Timeline returns the session type timeline that is active for the session started on the provided block
+func (c *Context) Timeline(sessionType types.SessionType, start uint64) *Timeline {
+	return c.TimelineVersions(sessionType).ByBlock(start).Timeline
+}
+
 func addCtxTypeKey(ctx context.Context, key ContextKey) context.Context {
 	return context.WithValue(ctx, ContextTypeKey, key)
 }
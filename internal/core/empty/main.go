@@ -10,45 +10,96 @@ import (
 )
 
 type Session struct {
+	id    uint64
 	nextF func() core.ISession
 	end   uint64
 	log   *logan.Entry
 }
 
-func NewEmptySession(ctx core.Context, info *config.SessionInfo, sessionType types.SessionType, creator func(ctx core.Context, id, startBlock uint64) core.ISession) *Session {
-	currentId := info.StartSessionId - 1
-	endBlock := info.StartBlock - 1
+// NewStartSessions returns the sessions to start the service with: the sessions running on the current block
+// that were stopped by restart and can be restored from the snapshots, and the empty session that waits
+// for the next session start if the latest started session can not be restored. Sessions are ordered by id.
+func NewStartSessions(ctx core.Context, info *config.SessionInfo, def *core.SessionDefinition) []core.ISession {
+	latestId, _ := getCurrentSession(ctx, info, def)
+	sessions := make([]core.ISession, 0, 1)
 
-	defer func() {
-		ctx.Log().Infof("[Empty Session] Running empty session for type=%s", sessionType.String())
-		ctx.Log().Infof("[Empty Session] ID = %d End = %d", currentId, endBlock)
-	}()
+	if latestId >= info.StartSessionId && def.RestoreSession != nil {
+		for _, id := range getActiveSessions(ctx, info, def, latestId) {
+			if session := def.RestoreSession(ctx, id); session != nil {
+				sessions = append(sessions, session)
+			}
+		}
+	}
 
-	if current := ctx.Timer().CurrentBlock(); current >= info.StartBlock {
-		currentId = GetSessionId(current, info.StartSessionId, info.StartBlock, sessionType)
-		endBlock = GetSessionEnd(currentId, info.StartBlock, sessionType)
+	if len(sessions) == 0 || sessions[len(sessions)-1].ID() != latestId {
+		sessions = append(sessions, NewEmptySession(ctx, info, def))
 	}
 
+	return sessions
+}
+
+func NewEmptySession(ctx core.Context, info *config.SessionInfo, def *core.SessionDefinition) *Session {
+	currentId, nextStart := getCurrentSession(ctx, info, def)
+
+	ctx.Log().Infof("[Empty Session] Running empty session for type=%s", def.Type.String())
+	ctx.Log().Infof("[Empty Session] ID = %d End = %d", currentId, nextStart-1)
+
 	return &Session{
+		id: currentId,
 		nextF: func() core.ISession {
-			return creator(ctx, currentId+1, endBlock+1)
+			return def.NewSession(ctx, currentId+1, nextStart)
 		},
-		end: endBlock,
+		end: nextStart - 1,
 		log: ctx.Log(),
 	}
 }
 
+// getCurrentSession returns the id of the latest session started before or on the current block
+// and the start block of the next session.
+func getCurrentSession(ctx core.Context, info *config.SessionInfo, def *core.SessionDefinition) (uint64, uint64) {
+	if current := ctx.Timer().CurrentBlock(); current >= info.StartBlock {
+		versions := ctx.TimelineVersions(def.Type)
+		currentId := GetSessionId(current, versions)
+		return currentId, GetSessionStart(currentId+1, versions)
+	}
+
+	return info.StartSessionId - 1, info.StartBlock
+}
+
+// getActiveSessions returns the ids of sessions that are running on the current block ordered by id.
+// Several sessions can be running at the same time if sessions are pipelined.
+func getActiveSessions(ctx core.Context, info *config.SessionInfo, def *core.SessionDefinition, latestId uint64) []uint64 {
+	current := ctx.Timer().CurrentBlock()
+	versions := ctx.TimelineVersions(def.Type)
+
+	first := latestId
+	for first > info.StartSessionId && GetSessionEnd(first-1, versions) >= current {
+		first--
+	}
+
+	ids := make([]uint64, 0, latestId-first+1)
+	for id := first; id <= latestId; id++ {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
 // Implements core.ISession interface
 var _ core.ISession = &Session{}
 
 func (s *Session) ID() uint64 {
-	return 0
+	return s.id
 }
 
 func (s *Session) End() uint64 {
 	return s.end
 }
 
+func (s *Session) NextStart() uint64 {
+	return s.end + 1
+}
+
 func (s *Session) Receive(context.Context, *types.MsgSubmitRequest) error {
 	return nil
 }
@@ -2,49 +2,47 @@ package empty
 
 import (
 	"github.com/rarimo/tss-svc/internal/core"
-	"github.com/rarimo/tss-svc/pkg/types"
 )
 
-// GetSessionId returns current session id based on: startId - session id to start from, startBlock - block
-// where session with startId started, current - current block, sessionType - type of the session.
+// GetSessionId returns the latest started session id based on: current - current block,
+// versions - the session type timeline versions. Every version defines startId - session id to start from,
+// startBlock - block where session with startId started, the session duration and the interval between
+// sessions starts. The id is calculated using the version that is active on the current block.
 // Example:
-// Lets take duration = 23 blocks and start = 10 block. So first three session will be on 10-33 34-57 58-81 blocks
+// Lets take duration = 23 blocks, interval = 24 blocks and start = 10 block. So first three session will be on 10-33 34-57 58-81 blocks
 // id = (current - start) / 24 + 1
 // current = 10 => id = (10 - 10) / 24 + 1 = 1
 // current = 33 => id = (33 - 10) / 24 + 1 = 1
-// current = 34 => id = (34 - 10) / 24 + 1 = 1 + 1 = 1
-func GetSessionId(current, startId, startBlock uint64, sessionType types.SessionType) uint64 {
-	switch sessionType {
-	case types.SessionType_DefaultSession:
-		return (current-startBlock)/(core.DefaultSessionDuration+1) + startId
-	case types.SessionType_KeygenSession:
-		return 1
-	case types.SessionType_ReshareSession:
-		return (current-startBlock)/(core.ReshareSessionDuration+1) + startId
-	}
-
-	// Should not appear
-	panic("Invalid session type")
+// current = 34 => id = (34 - 10) / 24 + 1 = 1 + 1 = 2
+// With interval = 12 blocks sessions will be on 10-33 22-45 34-57 blocks and on the block 25 the latest started session is 2.
+func GetSessionId(current uint64, versions core.TimelineVersions) uint64 {
+	version := versions.ByBlock(current)
+	return (current-version.StartBlock)/version.Timeline.Interval() + version.StartId
 }
 
-// GetSessionEnd returns session end based on: sessionId - current session id, startBlock - block
-// where the first session started, sessionType - type of the session.
+// GetSessionStart returns session start block based on: sessionId - session id,
+// versions - the session type timeline versions. The start is calculated using the version that is active
+// for the provided session.
 // Example:
-// Lets take duration = 23 blocks and start = 10 block. So first three session will be on 10-33 34-57 58-81 blocks
-// end = id*24 + start - 1
-// id = 1 => end = 1 * 24 + 10 - 1 = 33
-// id = 2 => end = 2 * 24 + 10 - 1 = 57
-// id = 3 => end = 3 * 24 + 10 - 1 = 81
-func GetSessionEnd(sessionId, startBlock uint64, sessionType types.SessionType) uint64 {
-	switch sessionType {
-	case types.SessionType_DefaultSession:
-		return sessionId*(core.DefaultSessionDuration+1) + startBlock - 1
-	case types.SessionType_KeygenSession:
-		return startBlock + core.KeygenSessionDuration
-	case types.SessionType_ReshareSession:
-		return sessionId*(core.ReshareSessionDuration+1) + startBlock - 1
-	}
+// Lets take interval = 24 blocks, start = 10 block and start id = 1.
+// start = (id - startId) * 24 + start
+// id = 1 => start = 0 * 24 + 10 = 10
+// id = 2 => start = 1 * 24 + 10 = 34
+// id = 3 => start = 2 * 24 + 10 = 58
+func GetSessionStart(sessionId uint64, versions core.TimelineVersions) uint64 {
+	version := versions.BySession(sessionId)
+	return (sessionId-version.StartId)*version.Timeline.Interval() + version.StartBlock
+}
 
-	// Should not appear
-	panic("Invalid session type")
+// GetSessionEnd returns session end based on: sessionId - session id,
+// versions - the session type timeline versions. The end is calculated using the version that is active
+// for the provided session.
+// Example:
+// Lets take duration = 23 blocks, interval = 24 blocks, start = 10 block and start id = 1. So first three session will be on 10-33 34-57 58-81 blocks
+// end = (id - startId) * 24 + start + 23
+// id = 1 => end = 0 * 24 + 10 + 23 = 33
+// id = 2 => end = 1 * 24 + 10 + 23 = 57
+// id = 3 => end = 2 * 24 + 10 + 23 = 81
+func GetSessionEnd(sessionId uint64, versions core.TimelineVersions) uint64 {
+	return GetSessionStart(sessionId, versions) + versions.BySession(sessionId).Timeline.SessionDuration
 }
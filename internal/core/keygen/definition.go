@@ -0,0 +1,63 @@
+package keygen
+
+import (
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/data/pg"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Definition returns the types.SessionType_KeygenSession definition to be registered in core.
+func Definition() *core.SessionDefinition {
+	return &core.SessionDefinition{
+		Type:       types.SessionType_KeygenSession,
+		Name:       "keygen",
+		ContextKey: core.KeygenSessionContextKey,
+		// Keygen sessions are scheduled as regular ones, but the next session is created only if the key
+		// has not been generated yet (see Session.NextSession)
+		Controllers: []types.ControllerType{
+			types.ControllerType_CONTROLLER_KEYGEN,
+		},
+		Timeline: &core.Timeline{
+			SessionDuration: core.KeygenSessionDuration,
+			DurationByController: map[types.ControllerType]uint64{
+				types.ControllerType_CONTROLLER_KEYGEN: core.KeygenSessionKeygenDuration,
+			},
+		},
+		NewSession: NewSession,
+		GetSession: GetSession,
+	}
+}
+
+// GetSession selects the keygen session entry and returns its API representation.
+func GetSession(storage *pg.Storage, id uint64) (*types.Session, error) {
+	session, err := storage.KeygenSessionDatumQ().KeygenSessionDatumByID(int64(id), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "error selecting session data by id")
+	}
+
+	if session == nil {
+		return nil, nil
+	}
+
+	details, err := anypb.New(&types.KeygenSessionData{
+		Parties:  session.Parties,
+		Key:      session.Key.String,
+		EddsaKey: session.EddsaKey.String,
+		Attempt:  uint64(session.Attempt),
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal data")
+	}
+
+	return &types.Session{
+		Id:         uint64(session.ID),
+		Status:     types.SessionStatus(session.Status),
+		StartBlock: uint64(session.BeginBlock),
+		EndBlock:   uint64(session.EndBlock),
+		Type:       types.SessionType_KeygenSession,
+		Data:       details,
+	}, nil
+}
@@ -11,32 +11,47 @@ import (
 	"gitlab.com/distributed_lab/logan/v3"
 )
 
-// Session represents key generation session that should be run only once as a first session
+// Session represents key generation session that is run as a first session.
+// Failed key generation is retried in the next session windows with the same parties set until the key is set up in core.
 type Session struct {
-	log    *logan.Entry
-	mu     sync.Mutex
-	id     uint64
-	bounds *core.BoundsManager
+	log     *logan.Entry
+	mu      sync.Mutex
+	id      uint64
+	attempt uint64
+	bounds  *core.BoundsManager
 
 	data      *controllers.LocalSessionData
 	current   controllers.IController
 	isStarted bool
 	cancel    context.CancelFunc
+	buffer    *controllers.RequestBuffer
+	auth      *core.RequestAuthorizer
+	ready     *controllers.ReadyTracker
 }
 
 // Implements core.ISession interface
 var _ core.ISession = &Session{}
 
+// NewSession creates the key generation attempt with provided id. Returns nil if the key has already been set up in core.
+// Local key share is not used to decide: party can hold the key share that has not been accepted by other parties.
 func NewSession(ctx core.Context, id, startBlock uint64) core.ISession {
-	data := controllers.NewSessionData(ctx, id, types.SessionType_KeygenSession)
+	data := controllers.NewSessionData(ctx, id, types.SessionType_KeygenSession, controllers.KeygenSessionPipeline)
+	if data.Set.GlobalPubKey != "" {
+		ctx.Log().Info("[Keygen session] Key has already been set up in core")
+		return nil
+	}
 
 	sess := &Session{
 		log:     ctx.Log().WithField("id", id).WithField("type", types.SessionType_KeygenSession.String()),
 		id:      id,
-		bounds:  core.NewBoundsManager(startBlock, types.SessionType_KeygenSession),
+		attempt: id - ctx.TimelineVersions(types.SessionType_KeygenSession)[0].StartId + 1,
+		bounds:  core.NewBoundsManager(startBlock, ctx.Timeline(types.SessionType_KeygenSession, startBlock)),
 		data:    data,
+		buffer:  controllers.NewRequestBuffer(),
 		current: data.GetKeygenController(),
 	}
+	sess.auth = core.NewRequestAuthorizer(sess.data.Set.Parties, sess.log)
+	sess.ready = controllers.NewReadyTracker(sess.data, sess.bounds)
 	sess.initSessionData(ctx)
 	return sess
 }
@@ -45,12 +60,30 @@ func (s *Session) ID() uint64 {
 	return s.id
 }
 
-func (s *Session) Receive(ctx context.Context, request *types.MsgSubmitRequest) error {
+func (s *Session) Receive(_ context.Context, request *types.MsgSubmitRequest) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if s.current != nil {
-		return s.current.Receive(core.GetSessionCtx(ctx, types.SessionType_KeygenSession), request)
+		// Request can belong to the next controller if sender has finished the current one earlier
+		if request.Data.Type == types.RequestType_Ready || !controllers.Accepts(s.current, request) {
+			sender, err := s.auth.Auth(request)
+			if err != nil {
+				return err
+			}
+
+			if request.Data.Type == types.RequestType_Ready {
+				return s.ready.Receive(sender, request)
+			}
+
+			if !s.buffer.Push(sender, request) {
+				s.log.Warn("Request buffer is full, request dropped")
+			}
+			return nil
+		}
+
+		ctx := s.data.Context()
+		return s.current.Receive(ctx.Context(), request)
 	}
 
 	return nil
@@ -72,11 +105,28 @@ func (s *Session) NewBlock(height uint64) {
 	}
 
 	if s.current != nil {
+		if !s.isStarted && s.current.Type() == types.ControllerType_CONTROLLER_KEYGEN && s.keySetUp() {
+			s.log.Info("[Keygen session] Key has been set up in core by the previous attempt, skipping key generation")
+			s.current = s.data.GetFinishController()
+		}
+
 		if !s.isStarted {
 			s.runController()
 		}
 
-		if s.bounds.Current().End <= height {
+		// Controller is finished before its bounds end when all expected parties have signaled ready
+		current := s.bounds.Current()
+		if controllers.IsDone(s.current) {
+			s.ready.Signal(s.current.Type(), current.Start, height)
+		}
+
+		finish, ready := s.ready.Finish(s.current.Type(), current.Start)
+		if current.End <= height || ready && finish <= height {
+			if ready && finish < current.End {
+				s.log.Infof("Controller %s finished earlier on block %d", s.current.Type().String(), finish)
+				s.bounds.FinishCurrent(finish)
+			}
+
 			s.stopController()
 			s.current = s.current.Next()
 			s.isStarted = false
@@ -84,19 +134,55 @@ func (s *Session) NewBlock(height uint64) {
 	}
 }
 
+// NextSession returns the next key generation attempt that starts right after the current one.
+// Returns nil if the key has been set up in core: all parties decide on the same core state, so they stop or retry together.
 func (s *Session) NextSession() core.ISession {
-	return nil
+	ctx := s.data.Context()
+	data := s.data.Next()
+	if data.Set.GlobalPubKey != "" {
+		return nil
+	}
+
+	s.log.Infof("[Keygen session] Key has not been set up in core in attempt %d, retrying from block %d", s.attempt, s.NextStart())
+
+	next := &Session{
+		log:     s.log.WithField("id", s.id+1).WithField("type", types.SessionType_KeygenSession.String()),
+		id:      s.id + 1,
+		attempt: s.attempt + 1,
+		bounds:  core.NewBoundsManager(s.NextStart(), ctx.Timeline(types.SessionType_KeygenSession, s.NextStart())),
+		data:    data,
+		buffer:  controllers.NewRequestBuffer(),
+		current: data.GetKeygenController(),
+	}
+	next.auth = core.NewRequestAuthorizer(next.data.Set.Parties, next.log)
+	next.ready = controllers.NewReadyTracker(next.data, next.bounds)
+
+	next.initSessionData(ctx)
+	return next
 }
 
 func (s *Session) End() uint64 {
 	return s.bounds.SessionEnd
 }
 
+func (s *Session) NextStart() uint64 {
+	return s.bounds.NextSessionStart()
+}
+
+// keySetUp returns true if the key has been set up in core. The setup message of the previous attempt can be
+// included after the next attempt has been created, so the key is checked again before the key generation.
+func (s *Session) keySetUp() bool {
+	ctx := s.data.Context()
+	return core.NewInputSet(ctx.Client()).GlobalPubKey != ""
+}
+
 func (s *Session) runController() {
 	if s.current != nil {
+		sessionCtx := s.data.Context()
 		var ctx context.Context
-		ctx, s.cancel = context.WithCancel(core.GetSessionCtx(context.TODO(), types.SessionType_KeygenSession))
+		ctx, s.cancel = context.WithCancel(sessionCtx.Context())
 		s.current.Run(ctx)
+		s.buffer.Flush(ctx, s.current)
 		s.isStarted = true
 		s.bounds.NextController(s.current.Type())
 	}
@@ -115,10 +201,10 @@ func (s *Session) initSessionData(ctx core.Context) {
 		Status:     int(types.SessionStatus_SessionProcessing),
 		BeginBlock: int64(s.bounds.SessionStart),
 		EndBlock:   int64(s.bounds.SessionEnd),
+		Attempt:    int(s.attempt),
 	})
 
 	if err != nil {
 		s.log.WithError(err).Error("Error creating session entry")
 	}
-
 }
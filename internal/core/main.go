@@ -5,12 +5,16 @@ import (
 	goerr "errors"
 	"sync"
 
+	"github.com/rarimo/tss-svc/internal/connectors"
 	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 )
 
 var (
 	ErrInvalidSessionID   = goerr.New("invalid session ID")
 	ErrInvalidSessionType = goerr.New("invalid session type")
+	// ErrEarlyRequestsBufferFull appears when there are too many requests for the next session
+	ErrEarlyRequestsBufferFull = goerr.New("early requests buffer is full")
 )
 
 // ISession represents session component that is responsible for launching first session controller,
@@ -18,67 +22,249 @@ var (
 type ISession interface {
 	ID() uint64
 	End() uint64
+	// NextStart returns the block the next session starts on.
+	// Next session can start before the current one ends if sessions are pipelined.
+	NextStart() uint64
 	Receive(ctx context.Context, request *types.MsgSubmitRequest) error
 	// NewBlock is a receiver for timer.Timer
 	NewBlock(height uint64)
 	NextSession() ISession
 }
 
-// SessionManager is responsible for managing session execution
+const (
+	// EarlyRequestsBufferSize defines the maximum amount of requests for the next session that can be buffered per session type
+	EarlyRequestsBufferSize = 256
+	// PreviousSessionBlocks defines the amount of blocks the previous session accepts late requests after session switching
+	PreviousSessionBlocks = 2
+)
+
+// previousSession holds the finished session to accept late requests until the provided block
+type previousSession struct {
+	session ISession
+	until   uint64
+}
+
+// SessionManager is responsible for managing session execution.
+// Several sessions of the same type can be active at the same time if sessions are pipelined.
 type SessionManager struct {
-	mu       sync.Mutex
-	sessions map[types.SessionType]ISession
+	mu sync.Mutex
+	// ctx is used to authorize early requests
+	ctx Context
+	// sessions contains active sessions ordered by id. The latest session is responsible for creating the next one.
+	sessions map[types.SessionType][]ISession
+	previous map[types.SessionType][]previousSession
+	early    map[types.SessionType][]*types.MsgSubmitRequest
+	replay   *ReplayGuard
+
+	// authMu guards the early requests authorizer that is built from the parties set fetched once per block.
+	// Parties are fetched from the core without holding mu, so sessions are not blocked by the core requests.
+	authMu     sync.Mutex
+	auth       *RequestAuthorizer
+	authHeight uint64
 }
 
-func NewSessionManager() *SessionManager {
+func NewSessionManager(ctx Context, replayWindow uint64) *SessionManager {
 	return &SessionManager{
-		sessions: make(map[types.SessionType]ISession),
+		ctx:      ctx,
+		sessions: make(map[types.SessionType][]ISession),
+		previous: make(map[types.SessionType][]previousSession),
+		early:    make(map[types.SessionType][]*types.MsgSubmitRequest),
+		replay:   NewReplayGuard(replayWindow),
 	}
 }
 
+// AddSession adds the active session. Sessions of the same type should be added in order of ids.
 func (s *SessionManager) AddSession(sessionType types.SessionType, session ISession) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.sessions[sessionType] = session
+	s.sessions[sessionType] = append(s.sessions[sessionType], session)
 }
 
+var _ connectors.Receiver = &SessionManager{}
+
+// Receive delivers the request to the active session of corresponding type and id.
+// Authenticated requests for the next session are buffered and will be delivered after the next session creation.
+// Requests for the finished sessions are delivered to them during PreviousSessionBlocks after finishing.
+// Stale requests are rejected (see ReplayGuard). Replayed request has already been delivered,
+// so it is acknowledged without delivering it again: the sender may resubmit the request if the response has been lost.
 func (s *SessionManager) Receive(ctx context.Context, request *types.MsgSubmitRequest) error {
+	var auth *RequestAuthorizer
+	if s.isEarly(request) {
+		var err error
+		if auth, err = s.earlyAuthorizer(); err != nil {
+			return err
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if session, ok := s.sessions[request.Data.SessionType]; ok && session != nil {
+	key, err := s.replay.Check(request, s.ctx.Timer().CurrentBlock())
+	if goerr.Is(err, ErrReplayedRequest) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.deliver(ctx, request, auth); err != nil {
+		return err
+	}
+
+	s.replay.Accept(key, request.Data.Height)
+	return nil
+}
+
+// isEarly checks if the request belongs to the next session that is not created yet.
+func (s *SessionManager) isEarly(request *types.MsgSubmitRequest) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions := s.sessions[request.Data.SessionType]
+	return len(sessions) > 0 && request.Data.Id == sessions[len(sessions)-1].ID()+1
+}
+
+// earlyAuthorizer returns the authorizer for the next session requests. Parties set is fetched once per block
+// because it can be changed.
+func (s *SessionManager) earlyAuthorizer() (*RequestAuthorizer, error) {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+
+	height := s.ctx.Timer().CurrentBlock()
+	if s.auth != nil && s.authHeight == height {
+		return s.auth, nil
+	}
+
+	parties, err := GetParties(s.ctx.Client())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get parties to authorize request")
+	}
+
+	s.auth = NewRequestAuthorizer(parties, s.ctx.Log())
+	s.authHeight = height
+	return s.auth, nil
+}
+
+// deliver routes the request to the session. Early requests are authorized by the provided authorizer.
+func (s *SessionManager) deliver(ctx context.Context, request *types.MsgSubmitRequest, auth *RequestAuthorizer) error {
+	sessions := s.sessions[request.Data.SessionType]
+	if len(sessions) == 0 {
+		return ErrInvalidSessionType
+	}
+
+	for _, session := range sessions {
 		if session.ID() == request.Data.Id {
 			return session.Receive(ctx, request)
 		}
+	}
 
-		return ErrInvalidSessionID
+	if request.Data.Id == sessions[len(sessions)-1].ID()+1 {
+		return s.pushEarly(request, auth)
 	}
 
-	return ErrInvalidSessionType
+	for _, prev := range s.previous[request.Data.SessionType] {
+		if prev.session.ID() == request.Data.Id {
+			return prev.session.Receive(ctx, request)
+		}
+	}
+
+	return ErrInvalidSessionID
 }
 
 func (s *SessionManager) NewBlock(height uint64) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for sessionType, session := range s.sessions {
-		if session != nil {
+	s.replay.Prune(height)
+
+	for sessionType, list := range s.previous {
+		actual := make([]previousSession, 0, len(list))
+		for _, prev := range list {
+			if prev.until >= height {
+				actual = append(actual, prev)
+			}
+		}
+
+		s.previous[sessionType] = actual
+	}
+
+	for sessionType, sessions := range s.sessions {
+		for _, session := range sessions {
 			session.NewBlock(height)
-			if session.End() <= height {
-				s.sessions[sessionType] = session.NextSession()
+		}
+
+		if len(sessions) == 0 {
+			continue
+		}
+
+		// next session should be launched on the next block
+		if latest := sessions[len(sessions)-1]; latest.NextStart() <= height+1 {
+			if next := latest.NextSession(); next != nil {
+				sessions = append(sessions, next)
+				s.replayEarly(sessionType, next)
+			}
+		}
+
+		active := make([]ISession, 0, len(sessions))
+		for _, session := range sessions {
+			if session.End() > height {
+				active = append(active, session)
+				continue
 			}
+
+			s.previous[sessionType] = append(s.previous[sessionType], previousSession{
+				session: session,
+				until:   height + PreviousSessionBlocks,
+			})
 		}
+
+		s.sessions[sessionType] = active
 	}
 
 	return nil
 }
 
+func (s *SessionManager) pushEarly(request *types.MsgSubmitRequest, auth *RequestAuthorizer) error {
+	// Next session has been changed after the request was checked, so the request is two sessions ahead
+	if auth == nil {
+		return ErrInvalidSessionID
+	}
+
+	if _, err := auth.Auth(request); err != nil {
+		return err
+	}
+
+	if len(s.early[request.Data.SessionType]) >= EarlyRequestsBufferSize {
+		return ErrEarlyRequestsBufferFull
+	}
+
+	s.early[request.Data.SessionType] = append(s.early[request.Data.SessionType], request)
+	return nil
+}
+
+func (s *SessionManager) replayEarly(sessionType types.SessionType, session ISession) {
+	requests := s.early[sessionType]
+	delete(s.early, sessionType)
+
+	for _, request := range requests {
+		if request.Data.Id != session.ID() {
+			continue
+		}
+
+		if err := session.Receive(context.TODO(), request); err != nil {
+			s.ctx.Log().WithError(err).Error("Error delivering early request")
+		}
+	}
+}
+
 func (s *SessionManager) ID(sessionType types.SessionType) (uint64, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if session, ok := s.sessions[sessionType]; ok && session != nil {
-		return session.ID(), true
+	// the latest session id
+	if sessions := s.sessions[sessionType]; len(sessions) > 0 {
+		return sessions[len(sessions)-1].ID(), true
 	}
 
 	return 0, false
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/rarimo/tss-svc/internal/data/pg"
+	"github.com/rarimo/tss-svc/pkg/types"
+)
+
+// SessionDefinition declares everything the service needs to know about the session type:
+// how the session is scheduled and created, how its timeline looks like and how it is represented in the API.
+type SessionDefinition struct {
+	Type types.SessionType
+	// Name is used as the session type key in configuration
+	Name string
+	// ContextKey defines the registry to store the session context values
+	ContextKey ContextKey
+	// Pipelined sessions can start before the previous session of the same type ends (see Timeline.SessionInterval)
+	Pipelined bool
+	// Controllers defines the longest sequence of controllers (excluding finish controller)
+	// that can be executed in the session. Used to validate the timelines.
+	Controllers []types.ControllerType
+	// Timeline is used if timeline is not configured for the session type
+	Timeline *Timeline
+	// NewSession creates the session with provided id that starts on the provided block
+	NewSession func(ctx Context, id, startBlock uint64) ISession
+	// RestoreSession restores the session with provided id from the stored snapshot after restart.
+	// Returns nil if session can not be restored. Optional.
+	RestoreSession func(ctx Context, id uint64) ISession
+	// GetSession selects the session entry and returns its API representation. Returns nil if entry does not exist.
+	GetSession func(storage *pg.Storage, id uint64) (*types.Session, error)
+}
+
+var definitions = struct {
+	mu   sync.RWMutex
+	defs map[types.SessionType]*SessionDefinition
+}{
+	defs: make(map[types.SessionType]*SessionDefinition),
+}
+
+// RegisterSession registers the session type definition. Should be called before Initialize.
+func RegisterSession(def *SessionDefinition) {
+	definitions.mu.Lock()
+	defer definitions.mu.Unlock()
+
+	if _, ok := definitions.defs[def.Type]; ok {
+		panic("session type already registered: " + def.Type.String())
+	}
+
+	definitions.defs[def.Type] = def
+}
+
+// GetSessionDefinition returns the registered session type definition
+func GetSessionDefinition(sessionType types.SessionType) (*SessionDefinition, bool) {
+	definitions.mu.RLock()
+	defer definitions.mu.RUnlock()
+
+	def, ok := definitions.defs[sessionType]
+	return def, ok
+}
+
+// MustGetSessionDefinition returns the registered session type definition or panics if type is not registered
+func MustGetSessionDefinition(sessionType types.SessionType) *SessionDefinition {
+	def, ok := GetSessionDefinition(sessionType)
+	if !ok {
+		// Should not appear
+		panic(ErrInvalidSessionType)
+	}
+
+	return def
+}
+
+// SessionDefinitions returns all registered session type definitions ordered by type
+func SessionDefinitions() []*SessionDefinition {
+	definitions.mu.RLock()
+	defer definitions.mu.RUnlock()
+
+	res := make([]*SessionDefinition, 0, len(definitions.defs))
+	for _, def := range definitions.defs {
+		res = append(res, def)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Type < res[j].Type
+	})
+
+	return res
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	goerr "errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rarimo/tss-svc/pkg/types"
+)
+
+var (
+	ErrReplayedRequest = goerr.New("request has already been received")
+	ErrStaleRequest    = goerr.New("request height is out of the acceptable window")
+)
+
+type replayKey struct {
+	signer   string
+	sequence uint64
+}
+
+// ReplayGuard rejects the requests that have already been received from the same signer with the same sequence
+// and the requests which height is too far from the current block.
+// Window defines the maximum difference between the request height and the current block.
+// Requests are remembered only inside the window, older requests are rejected as stale.
+type ReplayGuard struct {
+	mu     sync.Mutex
+	window uint64
+	seen   map[replayKey]uint64
+}
+
+func NewReplayGuard(window uint64) *ReplayGuard {
+	return &ReplayGuard{
+		window: window,
+		seen:   make(map[replayKey]uint64),
+	}
+}
+
+// Check verifies that the request is fresh and has not been received before. Returns the key that should be used to
+// Accept the request after successful processing, so the rejected requests can be resubmitted by the sender.
+func (g *ReplayGuard) Check(request *types.MsgSubmitRequest, current uint64) (replayKey, error) {
+	if request.Data.Height+g.window < current || request.Data.Height > current+g.window {
+		return replayKey{}, ErrStaleRequest
+	}
+
+	signer, err := RecoverSigner(request)
+	if err != nil {
+		return replayKey{}, ErrInvalidSignature
+	}
+
+	key := replayKey{signer: hexutil.Encode(signer), sequence: request.Data.Sequence}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.seen[key]; ok {
+		return replayKey{}, ErrReplayedRequest
+	}
+
+	return key, nil
+}
+
+// Accept remembers the request key until the request height leaves the window.
+func (g *ReplayGuard) Accept(key replayKey, height uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.seen[key] = height
+}
+
+// Prune removes the keys of requests that will be rejected as stale on the current block.
+func (g *ReplayGuard) Prune(current uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for key, height := range g.seen {
+		if height+g.window < current {
+			delete(g.seen, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package reshare
+
+import (
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/data/pg"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Definition returns the types.SessionType_ReshareSession definition to be registered in core.
+func Definition() *core.SessionDefinition {
+	return &core.SessionDefinition{
+		Type:       types.SessionType_ReshareSession,
+		Name:       "reshare",
+		ContextKey: core.ReshareSessionContextKey,
+		Controllers: []types.ControllerType{
+			types.ControllerType_CONTROLLER_PROPOSAL,
+			types.ControllerType_CONTROLLER_ACCEPTANCE,
+			types.ControllerType_CONTROLLER_KEYGEN,
+			types.ControllerType_CONTROLLER_SIGN,
+			types.ControllerType_CONTROLLER_SIGN,
+		},
+		Timeline: &core.Timeline{
+			SessionDuration: core.ReshareSessionDuration,
+			DurationByController: map[types.ControllerType]uint64{
+				types.ControllerType_CONTROLLER_PROPOSAL:   core.ReshareSessionProposalDuration,
+				types.ControllerType_CONTROLLER_ACCEPTANCE: core.ReshareSessionAcceptanceDuration,
+				types.ControllerType_CONTROLLER_KEYGEN:     core.ReshareSessionKeygenDuration,
+				types.ControllerType_CONTROLLER_SIGN:       core.ReshareSessionSignDuration,
+			},
+		},
+		NewSession:     NewSession,
+		RestoreSession: RestoreSession,
+		GetSession:     GetSession,
+	}
+}
+
+// GetSession selects the reshare session entry and returns its API representation.
+func GetSession(storage *pg.Storage, id uint64) (*types.Session, error) {
+	session, err := storage.ReshareSessionDatumQ().ReshareSessionDatumByID(int64(id), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "error selecting session by id")
+	}
+
+	if session == nil {
+		return nil, nil
+	}
+
+	details, err := anypb.New(&types.ReshareSessionData{
+		Parties:           session.Parties,
+		Proposer:          session.Proposer.String,
+		OldKey:            session.OldKey.String,
+		NewKey:            session.NewKey.String,
+		Root:              session.Root.String,
+		KeySignature:      session.KeySignature.String,
+		Signature:         session.Signature.String,
+		NewEdDSAKey:       session.NewEddsaKey.String,
+		EddsaKeySignature: session.EddsaKeySignature.String,
+		SignatureFailure:  session.SignatureFailure.String,
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal data")
+	}
+
+	return &types.Session{
+		Id:         uint64(session.ID),
+		Status:     types.SessionStatus(session.Status),
+		StartBlock: uint64(session.BeginBlock),
+		EndBlock:   uint64(session.EndBlock),
+		Type:       types.SessionType_ReshareSession,
+		Data:       details,
+	}, nil
+}
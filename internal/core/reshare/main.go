@@ -22,35 +22,86 @@ type Session struct {
 	current   controllers.IController
 	isStarted bool
 	cancel    context.CancelFunc
+	buffer    *controllers.RequestBuffer
+	auth      *core.RequestAuthorizer
+	ready     *controllers.ReadyTracker
 }
 
 // Implements core.ISession interface
 var _ core.ISession = &Session{}
 
 func NewSession(ctx core.Context, id, startBlock uint64) core.ISession {
-	data := controllers.NewSessionData(ctx, id, types.SessionType_ReshareSession)
+	data := controllers.NewSessionData(ctx, id, types.SessionType_ReshareSession, controllers.ReshareSessionPipeline)
 
 	sess := &Session{
 		log:     ctx.Log().WithField("id", id).WithField("type", types.SessionType_ReshareSession.String()),
 		id:      id,
-		bounds:  core.NewBoundsManager(startBlock, types.SessionType_ReshareSession),
+		bounds:  core.NewBoundsManager(startBlock, ctx.Timeline(types.SessionType_ReshareSession, startBlock)),
 		data:    data,
+		buffer:  controllers.NewRequestBuffer(),
 		current: data.GetProposalController(),
 	}
+	sess.auth = core.NewRequestAuthorizer(sess.data.Set.Parties, sess.log)
+	sess.ready = controllers.NewReadyTracker(sess.data, sess.bounds)
 	sess.initSessionData(ctx)
 	return sess
 }
 
+// RestoreSession restores the session from the snapshot stored before restart.
+// Returns nil if there is no snapshot for the session with provided id.
+func RestoreSession(ctx core.Context, id uint64) core.ISession {
+	restored, err := controllers.RestoreSession(ctx, id, types.SessionType_ReshareSession, controllers.ReshareSessionPipeline)
+	if err != nil {
+		ctx.Log().WithError(err).Error("Error restoring session")
+		return nil
+	}
+
+	if restored == nil {
+		return nil
+	}
+
+	sess := &Session{
+		log:     ctx.Log().WithField("id", id).WithField("type", types.SessionType_ReshareSession.String()),
+		id:      id,
+		bounds:  core.NewBoundsManager(restored.SessionStart, ctx.Timeline(types.SessionType_ReshareSession, restored.SessionStart)),
+		data:    restored.Data,
+		buffer:  controllers.NewRequestBuffer(),
+		current: restored.Controller,
+	}
+	sess.auth = core.NewRequestAuthorizer(sess.data.Set.Parties, sess.log)
+	sess.ready = controllers.NewReadyTracker(sess.data, sess.bounds)
+	sess.bounds.Resume(restored.ControllerStart)
+	return sess
+}
+
 func (s *Session) ID() uint64 {
 	return s.id
 }
 
-func (s *Session) Receive(ctx context.Context, request *types.MsgSubmitRequest) error {
+func (s *Session) Receive(_ context.Context, request *types.MsgSubmitRequest) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if s.current != nil {
-		return s.current.Receive(core.GetSessionCtx(ctx, types.SessionType_ReshareSession), request)
+		// Request can belong to the next controller if sender has finished the current one earlier
+		if request.Data.Type == types.RequestType_Ready || !controllers.Accepts(s.current, request) {
+			sender, err := s.auth.Auth(request)
+			if err != nil {
+				return err
+			}
+
+			if request.Data.Type == types.RequestType_Ready {
+				return s.ready.Receive(sender, request)
+			}
+
+			if !s.buffer.Push(sender, request) {
+				s.log.Warn("Request buffer is full, request dropped")
+			}
+			return nil
+		}
+
+		ctx := s.data.Context()
+		return s.current.Receive(ctx.Context(), request)
 	}
 
 	return nil
@@ -68,6 +119,7 @@ func (s *Session) NewBlock(height uint64) {
 
 	if s.bounds.SessionEnd <= height {
 		s.stopController()
+		s.deleteSnapshot()
 		return
 	}
 
@@ -76,25 +128,42 @@ func (s *Session) NewBlock(height uint64) {
 			s.runController()
 		}
 
-		if s.bounds.Current().End <= height {
+		// Controller is finished before its bounds end when all expected parties have signaled ready
+		current := s.bounds.Current()
+		if controllers.IsDone(s.current) {
+			s.ready.Signal(s.current.Type(), current.Start, height)
+		}
+
+		finish, ready := s.ready.Finish(s.current.Type(), current.Start)
+		if current.End <= height || ready && finish <= height {
+			if ready && finish < current.End {
+				s.log.Infof("Controller %s finished earlier on block %d", s.current.Type().String(), finish)
+				s.bounds.FinishCurrent(finish)
+			}
+
 			s.stopController()
 			s.current = s.current.Next()
 			s.isStarted = false
+			s.saveSnapshot(s.bounds.Current().End + 1)
 		}
 	}
 }
 
 func (s *Session) NextSession() core.ISession {
+	ctx := s.data.Context()
 	data := s.data.Next()
 	next := &Session{
 		log:     s.log.WithField("id", s.id+1).WithField("type", types.SessionType_ReshareSession.String()),
 		id:      s.id + 1,
-		bounds:  core.NewBoundsManager(s.End()+1, types.SessionType_ReshareSession),
+		bounds:  core.NewBoundsManager(s.NextStart(), ctx.Timeline(types.SessionType_ReshareSession, s.NextStart())),
 		data:    data,
+		buffer:  controllers.NewRequestBuffer(),
 		current: data.GetProposalController(),
 	}
+	next.auth = core.NewRequestAuthorizer(next.data.Set.Parties, next.log)
+	next.ready = controllers.NewReadyTracker(next.data, next.bounds)
 
-	next.initSessionData(core.DefaultSessionContext(types.SessionType_ReshareSession))
+	next.initSessionData(ctx)
 	return next
 }
 
@@ -102,11 +171,17 @@ func (s *Session) End() uint64 {
 	return s.bounds.SessionEnd
 }
 
+func (s *Session) NextStart() uint64 {
+	return s.bounds.NextSessionStart()
+}
+
 func (s *Session) runController() {
 	if s.current != nil {
+		sessionCtx := s.data.Context()
 		var ctx context.Context
-		ctx, s.cancel = context.WithCancel(core.GetSessionCtx(context.TODO(), types.SessionType_ReshareSession))
+		ctx, s.cancel = context.WithCancel(sessionCtx.Context())
 		s.current.Run(ctx)
+		s.buffer.Flush(ctx, s.current)
 		s.isStarted = true
 		s.bounds.NextController(s.current.Type())
 	}
@@ -131,3 +206,21 @@ func (s *Session) initSessionData(ctx core.Context) {
 		s.log.WithError(err).Error("Error creating session entry")
 	}
 }
+
+func (s *Session) saveSnapshot(controllerStart uint64) {
+	if s.current == nil {
+		return
+	}
+
+	ctx := s.data.Context()
+	if err := controllers.SaveSnapshot(ctx, s.data, s.current.Type(), s.bounds.SessionStart, controllerStart); err != nil {
+		s.log.WithError(err).Error("Error saving session snapshot")
+	}
+}
+
+func (s *Session) deleteSnapshot() {
+	ctx := s.data.Context()
+	if err := controllers.DeleteSnapshot(ctx, types.SessionType_ReshareSession, s.id); err != nil {
+		s.log.WithError(err).Error("Error deleting session snapshot")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/connectors"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 	"google.golang.org/grpc"
 )
 
@@ -37,6 +39,9 @@ func NewInputSet(client *grpc.ClientConn) *InputSet {
 
 	allParties := append(verifiedParties, unverifiedParties...)
 
+	// Connections to the parties that have changed their address or key are not valid anymore
+	connectors.InvalidateClients(tssP.Params.Parties)
+
 	return &InputSet{
 		IsActive:          !tssP.Params.IsUpdateRequired,
 		GlobalPubKey:      tssP.Params.KeyECDSA,
@@ -48,3 +53,20 @@ func NewInputSet(client *grpc.ClientConn) *InputSet {
 		LastSignature:     tssP.Params.LastSignature,
 	}
 }
+
+// GetParties returns the current parties set (all active and inactive parties).
+func GetParties(client *grpc.ClientConn) ([]*rarimo.Party, error) {
+	tssP, err := rarimo.NewQueryClient(client).Params(context.TODO(), &rarimo.QueryParamsRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query params")
+	}
+
+	parties := make([]*rarimo.Party, 0, len(tssP.Params.Parties))
+	for _, p := range tssP.Params.Parties {
+		if p.Status == rarimo.PartyStatus_Active || p.Status == rarimo.PartyStatus_Inactive {
+			parties = append(parties, p)
+		}
+	}
+
+	return parties, nil
+}
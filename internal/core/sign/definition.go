@@ -0,0 +1,71 @@
+package sign
+
+import (
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/data/pg"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Definition returns the types.SessionType_DefaultSession definition to be registered in core.
+func Definition() *core.SessionDefinition {
+	return &core.SessionDefinition{
+		Type:       types.SessionType_DefaultSession,
+		Name:       "default",
+		ContextKey: core.DefaultSessionContextKey,
+		Pipelined:  true,
+		Controllers: []types.ControllerType{
+			types.ControllerType_CONTROLLER_PROPOSAL,
+			types.ControllerType_CONTROLLER_ACCEPTANCE,
+			types.ControllerType_CONTROLLER_SIGN,
+		},
+		Timeline: &core.Timeline{
+			SessionDuration: core.DefaultSessionDuration,
+			DurationByController: map[types.ControllerType]uint64{
+				types.ControllerType_CONTROLLER_PROPOSAL:   core.DefaultSessionProposalDuration,
+				types.ControllerType_CONTROLLER_ACCEPTANCE: core.DefaultSessionAcceptanceDuration,
+				types.ControllerType_CONTROLLER_SIGN:       core.DefaultSessionSignDuration,
+			},
+		},
+		NewSession:     NewSession,
+		RestoreSession: RestoreSession,
+		GetSession:     GetSession,
+	}
+}
+
+// GetSession selects the default session entry and returns its API representation.
+func GetSession(storage *pg.Storage, id uint64) (*types.Session, error) {
+	session, err := storage.DefaultSessionDatumQ().DefaultSessionDatumByID(int64(id), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "error selecting session by id")
+	}
+
+	if session == nil {
+		return nil, nil
+	}
+
+	details, err := anypb.New(&types.DefaultSessionData{
+		Parties:          session.Parties,
+		Proposer:         session.Proposer.String,
+		Indexes:          session.Indexes,
+		Root:             session.Root.String,
+		Accepted:         session.Accepted,
+		Signature:        session.Signature.String,
+		EddsaSignature:   session.EddsaSignature.String,
+		SignatureFailure: session.SignatureFailure.String,
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal data")
+	}
+
+	return &types.Session{
+		Id:         uint64(session.ID),
+		Status:     types.SessionStatus(session.Status),
+		StartBlock: uint64(session.BeginBlock),
+		EndBlock:   uint64(session.EndBlock),
+		Type:       types.SessionType_DefaultSession,
+		Data:       details,
+	}, nil
+}
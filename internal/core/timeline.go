@@ -0,0 +1,255 @@
+package core
+
+import (
+	goerr "errors"
+
+	"github.com/rarimo/tss-svc/internal/config"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+var (
+	ErrInvalidTimeline         = goerr.New("invalid session timeline")
+	ErrInvalidTimelineSchedule = goerr.New("invalid timeline upgrades schedule")
+)
+
+// Timeline defines the session and controllers durations in blocks for the certain session type
+type Timeline struct {
+	SessionDuration uint64
+	// SessionInterval defines the amount of blocks between the starts of consecutive sessions.
+	// Zero value means that the next session starts after the previous one ends.
+	SessionInterval      uint64
+	DurationByController map[types.ControllerType]uint64
+}
+
+// Interval returns the amount of blocks between the starts of consecutive sessions.
+// Sessions are running concurrently if interval is less than session length (duration + 1).
+func (t *Timeline) Interval() uint64 {
+	if t.SessionInterval == 0 {
+		return t.SessionDuration + 1
+	}
+
+	return t.SessionInterval
+}
+
+// Validate checks that all session controllers have non-zero durations, the longest controllers sequence
+// leaves at least one block for the finish controller and the sessions interval is configured only for
+// the pipelined session types and does not exceed the session length (duration + 1).
+func (t *Timeline) Validate(def *SessionDefinition) error {
+	sessionType := def.Type
+
+	if t.SessionInterval != 0 && !def.Pipelined {
+		return errors.Wrap(ErrInvalidTimeline, "session type can not be pipelined", logan.F{
+			"type":     sessionType.String(),
+			"interval": t.SessionInterval,
+		})
+	}
+
+	// Interval equal to the session length (duration + 1) is the default non-overlapping layout
+	if t.SessionInterval > t.SessionDuration+1 {
+		return errors.Wrap(ErrInvalidTimeline, "session interval should not exceed session length", logan.F{
+			"type":             sessionType.String(),
+			"interval":         t.SessionInterval,
+			"session_duration": t.SessionDuration,
+		})
+	}
+
+	// Every controller occupies duration + 1 blocks
+	var total uint64
+	for _, c := range def.Controllers {
+		duration := t.DurationByController[c]
+		if duration == 0 {
+			return errors.Wrap(ErrInvalidTimeline, "controller duration should not be zero", logan.F{
+				"type":       sessionType.String(),
+				"controller": c.String(),
+			})
+		}
+
+		total += duration + 1
+	}
+
+	if total >= t.SessionDuration {
+		return errors.Wrap(ErrInvalidTimeline, "controllers do not leave space for finish controller", logan.F{
+			"type":                 sessionType.String(),
+			"session_duration":     t.SessionDuration,
+			"controllers_duration": total,
+		})
+	}
+
+	return nil
+}
+
+// Timelines contains timelines for all session types
+type Timelines map[types.SessionType]*Timeline
+
+// DefaultTimelines returns the default timelines of all registered session types
+func DefaultTimelines() Timelines {
+	timelines := make(Timelines)
+	for _, def := range SessionDefinitions() {
+		timelines[def.Type] = def.Timeline
+	}
+
+	return timelines
+}
+
+// NewTimelines returns the base timelines overridden by configured ones.
+// Returns an error if any of the resulting timelines has inconsistent layout.
+func NewTimelines(base Timelines, info config.TimelineInfo) (Timelines, error) {
+	timelines := make(Timelines, len(base))
+	for sessionType, timeline := range base {
+		timelines[sessionType] = timeline
+	}
+
+	for name := range info {
+		if _, ok := definitionByName(name); !ok {
+			return nil, errors.Wrap(ErrInvalidSessionType, "timeline configured for unknown session type", logan.F{"type": name})
+		}
+	}
+
+	for _, def := range SessionDefinitions() {
+		if timeline, ok := info[def.Name]; ok && timeline.Duration != 0 {
+			timelines[def.Type] = newTimeline(def, timeline)
+		}
+
+		if err := timelines[def.Type].Validate(def); err != nil {
+			return nil, err
+		}
+	}
+
+	return timelines, nil
+}
+
+func definitionByName(name string) (*SessionDefinition, bool) {
+	for _, def := range SessionDefinitions() {
+		if def.Name == name {
+			return def, true
+		}
+	}
+
+	return nil, false
+}
+
+func newTimeline(def *SessionDefinition, info config.SessionTimeline) *Timeline {
+	byType := map[types.ControllerType]uint64{
+		types.ControllerType_CONTROLLER_PROPOSAL:   info.Proposal,
+		types.ControllerType_CONTROLLER_ACCEPTANCE: info.Acceptance,
+		types.ControllerType_CONTROLLER_KEYGEN:     info.Keygen,
+		types.ControllerType_CONTROLLER_SIGN:       info.Sign,
+	}
+
+	timeline := &Timeline{
+		SessionDuration:      info.Duration,
+		SessionInterval:      info.Interval,
+		DurationByController: make(map[types.ControllerType]uint64),
+	}
+
+	for _, t := range def.Controllers {
+		timeline.DurationByController[t] = byType[t]
+	}
+
+	return timeline
+}
+
+// TimelineVersion defines the timeline that is active starting from the session with StartId that begins at StartBlock.
+type TimelineVersion struct {
+	StartBlock uint64
+	StartId    uint64
+	Timeline   *Timeline
+}
+
+// TimelineVersions contains the session type timeline versions ordered by activation.
+// Versions always start on the session start boundary, so all parties switch timelines at the same session.
+type TimelineVersions []*TimelineVersion
+
+// ByBlock returns the version that is active on the provided block
+func (v TimelineVersions) ByBlock(block uint64) *TimelineVersion {
+	for i := len(v) - 1; i > 0; i-- {
+		if v[i].StartBlock <= block {
+			return v[i]
+		}
+	}
+
+	return v[0]
+}
+
+// BySession returns the version that is active for the session with provided id
+func (v TimelineVersions) BySession(id uint64) *TimelineVersion {
+	for i := len(v) - 1; i > 0; i-- {
+		if v[i].StartId <= id {
+			return v[i]
+		}
+	}
+
+	return v[0]
+}
+
+// activate schedules the timeline to be active from the first session that begins at or after the provided height.
+func (v TimelineVersions) activate(height uint64, timeline *Timeline) TimelineVersions {
+	last := v[len(v)-1]
+	if height <= last.StartBlock {
+		last.Timeline = timeline
+		return v
+	}
+
+	period := last.Timeline.Interval()
+	sessions := (height - last.StartBlock + period - 1) / period
+
+	return append(v, &TimelineVersion{
+		StartBlock: last.StartBlock + sessions*period,
+		StartId:    last.StartId + sessions,
+		Timeline:   timeline,
+	})
+}
+
+// TimelineSchedule contains the timeline versions for all session types
+type TimelineSchedule map[types.SessionType]TimelineVersions
+
+// NewTimelineSchedule builds the timeline versions for all session types using configured initial timelines
+// and upgrades. Returns an error if upgrades are not ordered by height or any timeline has inconsistent layout.
+func NewTimelineSchedule(info *config.SessionInfo) (TimelineSchedule, error) {
+	current, err := NewTimelines(DefaultTimelines(), info.Timeline)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := make(TimelineSchedule, len(current))
+	for sessionType, timeline := range current {
+		schedule[sessionType] = TimelineVersions{
+			{
+				StartBlock: info.StartBlock,
+				StartId:    info.StartSessionId,
+				Timeline:   timeline,
+			},
+		}
+	}
+
+	var prevHeight uint64
+	for i, upgrade := range info.Upgrades {
+		if i > 0 && upgrade.Height <= prevHeight {
+			return nil, errors.Wrap(ErrInvalidTimelineSchedule, "upgrades should be ordered by height", logan.F{
+				"height":          upgrade.Height,
+				"previous_height": prevHeight,
+			})
+		}
+
+		next, err := NewTimelines(current, upgrade.Timeline)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid upgrade timeline", logan.F{"height": upgrade.Height})
+		}
+
+		for sessionType, timeline := range next {
+			// Timeline has not been changed by upgrade
+			if timeline == current[sessionType] {
+				continue
+			}
+
+			schedule[sessionType] = schedule[sessionType].activate(upgrade.Height, timeline)
+		}
+
+		current = next
+		prevHeight = upgrade.Height
+	}
+
+	return schedule, nil
+}
@@ -1,10 +1,12 @@
 package core
 
 import (
+	"encoding/binary"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
+	eth "github.com/ethereum/go-ethereum/crypto"
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
 )
 
@@ -17,9 +19,28 @@ func GetTssPartyKey(account string) *big.Int {
 }
 
 func PartyIds(parties []*rarimo.Party) tss.SortedPartyIDs {
+	return ShareIds(parties, 0)
+}
+
+// GetTssShareKey returns the tss party key of the account for the key share of provided generation.
+// Initial keygen produces the generation 0 that uses the account key. Every resharing increments the generation,
+// so the old and new resharing committees always have different party keys.
+func GetTssShareKey(account string, generation uint64) *big.Int {
+	key := GetTssPartyKey(account)
+	if generation == 0 {
+		return key
+	}
+
+	gen := make([]byte, 8)
+	binary.BigEndian.PutUint64(gen, generation)
+	return new(big.Int).SetBytes(eth.Keccak256(key.Bytes(), gen))
+}
+
+// ShareIds returns the sorted tss party ids of the parties for the key share of provided generation.
+func ShareIds(parties []*rarimo.Party, generation uint64) tss.SortedPartyIDs {
 	partyIds := make([]*tss.PartyID, 0, len(parties))
 	for _, party := range parties {
-		partyIds = append(partyIds, tss.NewPartyID(party.Account, party.Account, GetTssPartyKey(party.Account)))
+		partyIds = append(partyIds, tss.NewPartyID(party.Account, party.Account, GetTssShareKey(party.Account, generation)))
 	}
 
 	return tss.SortPartyIDs(partyIds)
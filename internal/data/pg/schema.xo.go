@@ -54,18 +54,18 @@ func (s Storage) DefaultSessionDatumQ() *DefaultSessionDatumQ {
 	return NewDefaultSessionDatumQ(s.DB())
 }
 
-var colsDefaultSessionDatum = `id, status, begin_block, end_block, parties, proposer, indexes, root, accepted, signature`
+var colsDefaultSessionDatum = `id, status, begin_block, end_block, parties, proposer, indexes, root, accepted, signature, eddsa_signature, signature_failure`
 
 // InsertCtx inserts a DefaultSessionDatum to the database.
 func (q DefaultSessionDatumQ) InsertCtx(ctx context.Context, dsd *data.DefaultSessionDatum) error {
 	// sql insert query, primary key must be provided
 	sqlstr := `INSERT INTO public.default_session_data (` +
-		`id, status, begin_block, end_block, parties, proposer, indexes, root, accepted, signature` +
+		`id, status, begin_block, end_block, parties, proposer, indexes, root, accepted, signature, eddsa_signature, signature_failure` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12` +
 		`)`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, dsd.ID, dsd.Status, dsd.BeginBlock, dsd.EndBlock, dsd.Parties, dsd.Proposer, dsd.Indexes, dsd.Root, dsd.Accepted, dsd.Signature)
+	err := q.db.ExecRawContext(ctx, sqlstr, dsd.ID, dsd.Status, dsd.BeginBlock, dsd.EndBlock, dsd.Parties, dsd.Proposer, dsd.Indexes, dsd.Root, dsd.Accepted, dsd.Signature, dsd.EddsaSignature, dsd.SignatureFailure)
 	return errors.Wrap(err, "failed to execute insert query")
 }
 
@@ -78,10 +78,10 @@ func (q DefaultSessionDatumQ) Insert(dsd *data.DefaultSessionDatum) error {
 func (q DefaultSessionDatumQ) UpdateCtx(ctx context.Context, dsd *data.DefaultSessionDatum) error {
 	// update with composite primary key
 	sqlstr := `UPDATE public.default_session_data SET ` +
-		`status = $1, begin_block = $2, end_block = $3, parties = $4, proposer = $5, indexes = $6, root = $7, accepted = $8, signature = $9 ` +
-		`WHERE id = $10`
+		`status = $1, begin_block = $2, end_block = $3, parties = $4, proposer = $5, indexes = $6, root = $7, accepted = $8, signature = $9, eddsa_signature = $10, signature_failure = $11 ` +
+		`WHERE id = $12`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, dsd.Status, dsd.BeginBlock, dsd.EndBlock, dsd.Parties, dsd.Proposer, dsd.Indexes, dsd.Root, dsd.Accepted, dsd.Signature, dsd.ID)
+	err := q.db.ExecRawContext(ctx, sqlstr, dsd.Status, dsd.BeginBlock, dsd.EndBlock, dsd.Parties, dsd.Proposer, dsd.Indexes, dsd.Root, dsd.Accepted, dsd.Signature, dsd.EddsaSignature, dsd.SignatureFailure, dsd.ID)
 	return errors.Wrap(err, "failed to execute update")
 }
 
@@ -94,15 +94,15 @@ func (q DefaultSessionDatumQ) Update(dsd *data.DefaultSessionDatum) error {
 func (q DefaultSessionDatumQ) UpsertCtx(ctx context.Context, dsd *data.DefaultSessionDatum) error {
 	// upsert
 	sqlstr := `INSERT INTO public.default_session_data (` +
-		`id, status, begin_block, end_block, parties, proposer, indexes, root, accepted, signature` +
+		`id, status, begin_block, end_block, parties, proposer, indexes, root, accepted, signature, eddsa_signature, signature_failure` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`status = EXCLUDED.status, begin_block = EXCLUDED.begin_block, end_block = EXCLUDED.end_block, parties = EXCLUDED.parties, proposer = EXCLUDED.proposer, indexes = EXCLUDED.indexes, root = EXCLUDED.root, accepted = EXCLUDED.accepted, signature = EXCLUDED.signature `
+		`status = EXCLUDED.status, begin_block = EXCLUDED.begin_block, end_block = EXCLUDED.end_block, parties = EXCLUDED.parties, proposer = EXCLUDED.proposer, indexes = EXCLUDED.indexes, root = EXCLUDED.root, accepted = EXCLUDED.accepted, signature = EXCLUDED.signature, eddsa_signature = EXCLUDED.eddsa_signature, signature_failure = EXCLUDED.signature_failure `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, dsd.ID, dsd.Status, dsd.BeginBlock, dsd.EndBlock, dsd.Parties, dsd.Proposer, dsd.Indexes, dsd.Root, dsd.Accepted, dsd.Signature); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, dsd.ID, dsd.Status, dsd.BeginBlock, dsd.EndBlock, dsd.Parties, dsd.Proposer, dsd.Indexes, dsd.Root, dsd.Accepted, dsd.Signature, dsd.EddsaSignature, dsd.SignatureFailure); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
@@ -236,18 +236,18 @@ func (s Storage) KeygenSessionDatumQ() *KeygenSessionDatumQ {
 	return NewKeygenSessionDatumQ(s.DB())
 }
 
-var colsKeygenSessionDatum = `id, status, begin_block, end_block, parties, key`
+var colsKeygenSessionDatum = `id, status, begin_block, end_block, parties, key, eddsa_key, attempt`
 
 // InsertCtx inserts a KeygenSessionDatum to the database.
 func (q KeygenSessionDatumQ) InsertCtx(ctx context.Context, ksd *data.KeygenSessionDatum) error {
 	// sql insert query, primary key must be provided
 	sqlstr := `INSERT INTO public.keygen_session_data (` +
-		`id, status, begin_block, end_block, parties, key` +
+		`id, status, begin_block, end_block, parties, key, eddsa_key, attempt` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7, $8` +
 		`)`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, ksd.ID, ksd.Status, ksd.BeginBlock, ksd.EndBlock, ksd.Parties, ksd.Key)
+	err := q.db.ExecRawContext(ctx, sqlstr, ksd.ID, ksd.Status, ksd.BeginBlock, ksd.EndBlock, ksd.Parties, ksd.Key, ksd.EddsaKey, ksd.Attempt)
 	return errors.Wrap(err, "failed to execute insert query")
 }
 
@@ -260,10 +260,10 @@ func (q KeygenSessionDatumQ) Insert(ksd *data.KeygenSessionDatum) error {
 func (q KeygenSessionDatumQ) UpdateCtx(ctx context.Context, ksd *data.KeygenSessionDatum) error {
 	// update with composite primary key
 	sqlstr := `UPDATE public.keygen_session_data SET ` +
-		`status = $1, begin_block = $2, end_block = $3, parties = $4, key = $5 ` +
-		`WHERE id = $6`
+		`status = $1, begin_block = $2, end_block = $3, parties = $4, key = $5, eddsa_key = $6, attempt = $7 ` +
+		`WHERE id = $8`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, ksd.Status, ksd.BeginBlock, ksd.EndBlock, ksd.Parties, ksd.Key, ksd.ID)
+	err := q.db.ExecRawContext(ctx, sqlstr, ksd.Status, ksd.BeginBlock, ksd.EndBlock, ksd.Parties, ksd.Key, ksd.EddsaKey, ksd.Attempt, ksd.ID)
 	return errors.Wrap(err, "failed to execute update")
 }
 
@@ -276,15 +276,15 @@ func (q KeygenSessionDatumQ) Update(ksd *data.KeygenSessionDatum) error {
 func (q KeygenSessionDatumQ) UpsertCtx(ctx context.Context, ksd *data.KeygenSessionDatum) error {
 	// upsert
 	sqlstr := `INSERT INTO public.keygen_session_data (` +
-		`id, status, begin_block, end_block, parties, key` +
+		`id, status, begin_block, end_block, parties, key, eddsa_key, attempt` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6` +
+		`$1, $2, $3, $4, $5, $6, $7, $8` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`status = EXCLUDED.status, begin_block = EXCLUDED.begin_block, end_block = EXCLUDED.end_block, parties = EXCLUDED.parties, key = EXCLUDED.key `
+		`status = EXCLUDED.status, begin_block = EXCLUDED.begin_block, end_block = EXCLUDED.end_block, parties = EXCLUDED.parties, key = EXCLUDED.key, eddsa_key = EXCLUDED.eddsa_key, attempt = EXCLUDED.attempt `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, ksd.ID, ksd.Status, ksd.BeginBlock, ksd.EndBlock, ksd.Parties, ksd.Key); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, ksd.ID, ksd.Status, ksd.BeginBlock, ksd.EndBlock, ksd.Parties, ksd.Key, ksd.EddsaKey, ksd.Attempt); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
@@ -327,18 +327,18 @@ func (s Storage) ReshareSessionDatumQ() *ReshareSessionDatumQ {
 	return NewReshareSessionDatumQ(s.DB())
 }
 
-var colsReshareSessionDatum = `id, status, begin_block, end_block, parties, proposer, old_key, new_key, key_signature, signature, root`
+var colsReshareSessionDatum = `id, status, begin_block, end_block, parties, proposer, old_key, new_key, key_signature, signature, root, new_eddsa_key, eddsa_key_signature, signature_failure`
 
 // InsertCtx inserts a ReshareSessionDatum to the database.
 func (q ReshareSessionDatumQ) InsertCtx(ctx context.Context, rsd *data.ReshareSessionDatum) error {
 	// sql insert query, primary key must be provided
 	sqlstr := `INSERT INTO public.reshare_session_data (` +
-		`id, status, begin_block, end_block, parties, proposer, old_key, new_key, key_signature, signature, root` +
+		`id, status, begin_block, end_block, parties, proposer, old_key, new_key, key_signature, signature, root, new_eddsa_key, eddsa_key_signature, signature_failure` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14` +
 		`)`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, rsd.ID, rsd.Status, rsd.BeginBlock, rsd.EndBlock, rsd.Parties, rsd.Proposer, rsd.OldKey, rsd.NewKey, rsd.KeySignature, rsd.Signature, rsd.Root)
+	err := q.db.ExecRawContext(ctx, sqlstr, rsd.ID, rsd.Status, rsd.BeginBlock, rsd.EndBlock, rsd.Parties, rsd.Proposer, rsd.OldKey, rsd.NewKey, rsd.KeySignature, rsd.Signature, rsd.Root, rsd.NewEddsaKey, rsd.EddsaKeySignature, rsd.SignatureFailure)
 	return errors.Wrap(err, "failed to execute insert query")
 }
 
@@ -351,10 +351,10 @@ func (q ReshareSessionDatumQ) Insert(rsd *data.ReshareSessionDatum) error {
 func (q ReshareSessionDatumQ) UpdateCtx(ctx context.Context, rsd *data.ReshareSessionDatum) error {
 	// update with composite primary key
 	sqlstr := `UPDATE public.reshare_session_data SET ` +
-		`status = $1, begin_block = $2, end_block = $3, parties = $4, proposer = $5, old_key = $6, new_key = $7, key_signature = $8, signature = $9, root = $10 ` +
-		`WHERE id = $11`
+		`status = $1, begin_block = $2, end_block = $3, parties = $4, proposer = $5, old_key = $6, new_key = $7, key_signature = $8, signature = $9, root = $10, new_eddsa_key = $11, eddsa_key_signature = $12, signature_failure = $13 ` +
+		`WHERE id = $14`
 	// run
-	err := q.db.ExecRawContext(ctx, sqlstr, rsd.Status, rsd.BeginBlock, rsd.EndBlock, rsd.Parties, rsd.Proposer, rsd.OldKey, rsd.NewKey, rsd.KeySignature, rsd.Signature, rsd.Root, rsd.ID)
+	err := q.db.ExecRawContext(ctx, sqlstr, rsd.Status, rsd.BeginBlock, rsd.EndBlock, rsd.Parties, rsd.Proposer, rsd.OldKey, rsd.NewKey, rsd.KeySignature, rsd.Signature, rsd.Root, rsd.NewEddsaKey, rsd.EddsaKeySignature, rsd.SignatureFailure, rsd.ID)
 	return errors.Wrap(err, "failed to execute update")
 }
 
@@ -367,15 +367,15 @@ func (q ReshareSessionDatumQ) Update(rsd *data.ReshareSessionDatum) error {
 func (q ReshareSessionDatumQ) UpsertCtx(ctx context.Context, rsd *data.ReshareSessionDatum) error {
 	// upsert
 	sqlstr := `INSERT INTO public.reshare_session_data (` +
-		`id, status, begin_block, end_block, parties, proposer, old_key, new_key, key_signature, signature, root` +
+		`id, status, begin_block, end_block, parties, proposer, old_key, new_key, key_signature, signature, root, new_eddsa_key, eddsa_key_signature, signature_failure` +
 		`) VALUES (` +
-		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14` +
 		`)` +
 		` ON CONFLICT (id) DO ` +
 		`UPDATE SET ` +
-		`status = EXCLUDED.status, begin_block = EXCLUDED.begin_block, end_block = EXCLUDED.end_block, parties = EXCLUDED.parties, proposer = EXCLUDED.proposer, old_key = EXCLUDED.old_key, new_key = EXCLUDED.new_key, key_signature = EXCLUDED.key_signature, signature = EXCLUDED.signature, root = EXCLUDED.root `
+		`status = EXCLUDED.status, begin_block = EXCLUDED.begin_block, end_block = EXCLUDED.end_block, parties = EXCLUDED.parties, proposer = EXCLUDED.proposer, old_key = EXCLUDED.old_key, new_key = EXCLUDED.new_key, key_signature = EXCLUDED.key_signature, signature = EXCLUDED.signature, root = EXCLUDED.root, new_eddsa_key = EXCLUDED.new_eddsa_key, eddsa_key_signature = EXCLUDED.eddsa_key_signature, signature_failure = EXCLUDED.signature_failure `
 	// run
-	if err := q.db.ExecRawContext(ctx, sqlstr, rsd.ID, rsd.Status, rsd.BeginBlock, rsd.EndBlock, rsd.Parties, rsd.Proposer, rsd.OldKey, rsd.NewKey, rsd.KeySignature, rsd.Signature, rsd.Root); err != nil {
+	if err := q.db.ExecRawContext(ctx, sqlstr, rsd.ID, rsd.Status, rsd.BeginBlock, rsd.EndBlock, rsd.Parties, rsd.Proposer, rsd.OldKey, rsd.NewKey, rsd.KeySignature, rsd.Signature, rsd.Root, rsd.NewEddsaKey, rsd.EddsaKeySignature, rsd.SignatureFailure); err != nil {
 		return errors.Wrap(err, "failed to execute upsert stmt")
 	}
 	return nil
@@ -401,6 +401,97 @@ func (q ReshareSessionDatumQ) DeleteCtx(ctx context.Context, rsd *data.ReshareSe
 // Delete deletes the ReshareSessionDatum from the database.
 func (q ReshareSessionDatumQ) Delete(rsd *data.ReshareSessionDatum) error {
 	return q.DeleteCtx(context.Background(), rsd)
+} // SessionSnapshotQ represents helper struct to access row of 'session_snapshots'.
+type SessionSnapshotQ struct {
+	db *pgdb.DB
+}
+
+// NewSessionSnapshotQ  - creates new instance
+func NewSessionSnapshotQ(db *pgdb.DB) *SessionSnapshotQ {
+	return &SessionSnapshotQ{
+		db,
+	}
+}
+
+// SessionSnapshotQ  - creates new instance of SessionSnapshotQ
+func (s Storage) SessionSnapshotQ() *SessionSnapshotQ {
+	return NewSessionSnapshotQ(s.DB())
+}
+
+var colsSessionSnapshot = `session_type, session_id, controller, begin_block, controller_block, processing, proposer, indexes, root, acceptances, signers, offenders, is_signer, operation_signature, key_signature, eddsa_operation_signature, eddsa_key_signature, culprits`
+
+// InsertCtx inserts a SessionSnapshot to the database.
+func (q SessionSnapshotQ) InsertCtx(ctx context.Context, ss *data.SessionSnapshot) error {
+	// sql insert query, primary key must be provided
+	sqlstr := `INSERT INTO public.session_snapshots (` +
+		`session_type, session_id, controller, begin_block, controller_block, processing, proposer, indexes, root, acceptances, signers, offenders, is_signer, operation_signature, key_signature, eddsa_operation_signature, eddsa_key_signature, culprits` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18` +
+		`)`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, ss.SessionType, ss.SessionID, ss.Controller, ss.BeginBlock, ss.ControllerBlock, ss.Processing, ss.Proposer, ss.Indexes, ss.Root, ss.Acceptances, ss.Signers, ss.Offenders, ss.IsSigner, ss.OperationSignature, ss.KeySignature, ss.EddsaOperationSignature, ss.EddsaKeySignature, ss.Culprits)
+	return errors.Wrap(err, "failed to execute insert query")
+}
+
+// Insert insert a SessionSnapshot to the database.
+func (q SessionSnapshotQ) Insert(ss *data.SessionSnapshot) error {
+	return q.InsertCtx(context.Background(), ss)
+}
+
+// UpdateCtx updates a SessionSnapshot in the database.
+func (q SessionSnapshotQ) UpdateCtx(ctx context.Context, ss *data.SessionSnapshot) error {
+	// update with composite primary key
+	sqlstr := `UPDATE public.session_snapshots SET ` +
+		`controller = $1, begin_block = $2, controller_block = $3, processing = $4, proposer = $5, indexes = $6, root = $7, acceptances = $8, signers = $9, offenders = $10, is_signer = $11, operation_signature = $12, key_signature = $13, eddsa_operation_signature = $14, eddsa_key_signature = $15, culprits = $16 ` +
+		`WHERE session_type = $17 AND session_id = $18`
+	// run
+	err := q.db.ExecRawContext(ctx, sqlstr, ss.Controller, ss.BeginBlock, ss.ControllerBlock, ss.Processing, ss.Proposer, ss.Indexes, ss.Root, ss.Acceptances, ss.Signers, ss.Offenders, ss.IsSigner, ss.OperationSignature, ss.KeySignature, ss.EddsaOperationSignature, ss.EddsaKeySignature, ss.Culprits, ss.SessionType, ss.SessionID)
+	return errors.Wrap(err, "failed to execute update")
+}
+
+// Update updates a SessionSnapshot in the database.
+func (q SessionSnapshotQ) Update(ss *data.SessionSnapshot) error {
+	return q.UpdateCtx(context.Background(), ss)
+}
+
+// UpsertCtx performs an upsert for SessionSnapshot.
+func (q SessionSnapshotQ) UpsertCtx(ctx context.Context, ss *data.SessionSnapshot) error {
+	// upsert
+	sqlstr := `INSERT INTO public.session_snapshots (` +
+		`session_type, session_id, controller, begin_block, controller_block, processing, proposer, indexes, root, acceptances, signers, offenders, is_signer, operation_signature, key_signature, eddsa_operation_signature, eddsa_key_signature, culprits` +
+		`) VALUES (` +
+		`$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18` +
+		`)` +
+		` ON CONFLICT (session_type, session_id) DO ` +
+		`UPDATE SET ` +
+		`controller = EXCLUDED.controller, begin_block = EXCLUDED.begin_block, controller_block = EXCLUDED.controller_block, processing = EXCLUDED.processing, proposer = EXCLUDED.proposer, indexes = EXCLUDED.indexes, root = EXCLUDED.root, acceptances = EXCLUDED.acceptances, signers = EXCLUDED.signers, offenders = EXCLUDED.offenders, is_signer = EXCLUDED.is_signer, operation_signature = EXCLUDED.operation_signature, key_signature = EXCLUDED.key_signature, eddsa_operation_signature = EXCLUDED.eddsa_operation_signature, eddsa_key_signature = EXCLUDED.eddsa_key_signature, culprits = EXCLUDED.culprits `
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, ss.SessionType, ss.SessionID, ss.Controller, ss.BeginBlock, ss.ControllerBlock, ss.Processing, ss.Proposer, ss.Indexes, ss.Root, ss.Acceptances, ss.Signers, ss.Offenders, ss.IsSigner, ss.OperationSignature, ss.KeySignature, ss.EddsaOperationSignature, ss.EddsaKeySignature, ss.Culprits); err != nil {
+		return errors.Wrap(err, "failed to execute upsert stmt")
+	}
+	return nil
+}
+
+// Upsert performs an upsert for SessionSnapshot.
+func (q SessionSnapshotQ) Upsert(ss *data.SessionSnapshot) error {
+	return q.UpsertCtx(context.Background(), ss)
+}
+
+// DeleteCtx deletes the SessionSnapshot from the database.
+func (q SessionSnapshotQ) DeleteCtx(ctx context.Context, ss *data.SessionSnapshot) error {
+	// delete with composite primary key
+	sqlstr := `DELETE FROM public.session_snapshots ` +
+		`WHERE session_type = $1 AND session_id = $2`
+	// run
+	if err := q.db.ExecRawContext(ctx, sqlstr, ss.SessionType, ss.SessionID); err != nil {
+		return errors.Wrap(err, "failed to exec delete stmt")
+	}
+	return nil
+}
+
+// Delete deletes the SessionSnapshot from the database.
+func (q SessionSnapshotQ) Delete(ss *data.SessionSnapshot) error {
+	return q.DeleteCtx(context.Background(), ss)
 }
 
 // DefaultSessionDatumByIDCtx retrieves a row from 'public.default_session_data' as a DefaultSessionDatum.
@@ -409,7 +500,7 @@ func (q ReshareSessionDatumQ) Delete(rsd *data.ReshareSessionDatum) error {
 func (q DefaultSessionDatumQ) DefaultSessionDatumByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.DefaultSessionDatum, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, status, begin_block, end_block, parties, proposer, indexes, root, accepted, signature ` +
+		`id, status, begin_block, end_block, parties, proposer, indexes, root, accepted, signature, eddsa_signature, signature_failure ` +
 		`FROM public.default_session_data ` +
 		`WHERE id = $1`
 	// run
@@ -475,7 +566,7 @@ func (q GorpMigrationQ) GorpMigrationByID(id string, isForUpdate bool) (*data.Go
 func (q KeygenSessionDatumQ) KeygenSessionDatumByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.KeygenSessionDatum, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, status, begin_block, end_block, parties, key ` +
+		`id, status, begin_block, end_block, parties, key, eddsa_key, attempt ` +
 		`FROM public.keygen_session_data ` +
 		`WHERE id = $1`
 	// run
@@ -508,7 +599,7 @@ func (q KeygenSessionDatumQ) KeygenSessionDatumByID(id int64, isForUpdate bool)
 func (q ReshareSessionDatumQ) ReshareSessionDatumByIDCtx(ctx context.Context, id int64, isForUpdate bool) (*data.ReshareSessionDatum, error) {
 	// query
 	sqlstr := `SELECT ` +
-		`id, status, begin_block, end_block, parties, proposer, old_key, new_key, key_signature, signature, root ` +
+		`id, status, begin_block, end_block, parties, proposer, old_key, new_key, key_signature, signature, root, new_eddsa_key, eddsa_key_signature, signature_failure ` +
 		`FROM public.reshare_session_data ` +
 		`WHERE id = $1`
 	// run
@@ -534,3 +625,36 @@ func (q ReshareSessionDatumQ) ReshareSessionDatumByIDCtx(ctx context.Context, id
 func (q ReshareSessionDatumQ) ReshareSessionDatumByID(id int64, isForUpdate bool) (*data.ReshareSessionDatum, error) {
 	return q.ReshareSessionDatumByIDCtx(context.Background(), id, isForUpdate)
 }
+
+// SessionSnapshotBySessionTypeSessionIDCtx retrieves a row from 'public.session_snapshots' as a SessionSnapshot.
+//
+// Generated from index 'session_snapshots_pkey'.
+func (q SessionSnapshotQ) SessionSnapshotBySessionTypeSessionIDCtx(ctx context.Context, sessionType int, sessionID int64, isForUpdate bool) (*data.SessionSnapshot, error) {
+	// query
+	sqlstr := `SELECT ` +
+		`session_type, session_id, controller, begin_block, controller_block, processing, proposer, indexes, root, acceptances, signers, offenders, is_signer, operation_signature, key_signature, eddsa_operation_signature, eddsa_key_signature, culprits ` +
+		`FROM public.session_snapshots ` +
+		`WHERE session_type = $1 AND session_id = $2`
+	// run
+	if isForUpdate {
+		sqlstr += " for update"
+	}
+	var res data.SessionSnapshot
+	err := q.db.GetRawContext(ctx, &res, sqlstr, sessionType, sessionID)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to exec select")
+	}
+
+	return &res, nil
+}
+
+// SessionSnapshotBySessionTypeSessionID retrieves a row from 'public.session_snapshots' as a SessionSnapshot.
+//
+// Generated from index 'session_snapshots_pkey'.
+func (q SessionSnapshotQ) SessionSnapshotBySessionTypeSessionID(sessionType int, sessionID int64, isForUpdate bool) (*data.SessionSnapshot, error) {
+	return q.SessionSnapshotBySessionTypeSessionIDCtx(context.Background(), sessionType, sessionID, isForUpdate)
+}
@@ -61,16 +61,18 @@ func (ss StringSlice) Value() (driver.Value, error) {
 	return "{" + strings.Join(v, ",") + "}", nil
 } // DefaultSessionDatum represents a row from 'public.default_session_data'.
 type DefaultSessionDatum struct {
-	ID         int64          `db:"id"`          // id
-	Status     int            `db:"status"`      // status
-	BeginBlock int64          `db:"begin_block"` // begin_block
-	EndBlock   int64          `db:"end_block"`   // end_block
-	Parties    StringSlice    `db:"parties"`     // parties
-	Proposer   sql.NullString `db:"proposer"`    // proposer
-	Indexes    StringSlice    `db:"indexes"`     // indexes
-	Root       sql.NullString `db:"root"`        // root
-	Accepted   StringSlice    `db:"accepted"`    // accepted
-	Signature  sql.NullString `db:"signature"`   // signature
+	ID               int64          `db:"id"`                // id
+	Status           int            `db:"status"`            // status
+	BeginBlock       int64          `db:"begin_block"`       // begin_block
+	EndBlock         int64          `db:"end_block"`         // end_block
+	Parties          StringSlice    `db:"parties"`           // parties
+	Proposer         sql.NullString `db:"proposer"`          // proposer
+	Indexes          StringSlice    `db:"indexes"`           // indexes
+	Root             sql.NullString `db:"root"`              // root
+	Accepted         StringSlice    `db:"accepted"`          // accepted
+	Signature        sql.NullString `db:"signature"`         // signature
+	EddsaSignature   sql.NullString `db:"eddsa_signature"`   // eddsa_signature
+	SignatureFailure sql.NullString `db:"signature_failure"` // signature_failure
 
 }
 
@@ -89,21 +91,49 @@ type KeygenSessionDatum struct {
 	EndBlock   int64          `db:"end_block"`   // end_block
 	Parties    StringSlice    `db:"parties"`     // parties
 	Key        sql.NullString `db:"key"`         // key
+	EddsaKey   sql.NullString `db:"eddsa_key"`   // eddsa_key
+	Attempt    int            `db:"attempt"`     // attempt
 
 }
 
 // ReshareSessionDatum represents a row from 'public.reshare_session_data'.
 type ReshareSessionDatum struct {
-	ID           int64          `db:"id"`            // id
-	Status       int            `db:"status"`        // status
-	BeginBlock   int64          `db:"begin_block"`   // begin_block
-	EndBlock     int64          `db:"end_block"`     // end_block
-	Parties      StringSlice    `db:"parties"`       // parties
-	Proposer     sql.NullString `db:"proposer"`      // proposer
-	OldKey       sql.NullString `db:"old_key"`       // old_key
-	NewKey       sql.NullString `db:"new_key"`       // new_key
-	KeySignature sql.NullString `db:"key_signature"` // key_signature
-	Signature    sql.NullString `db:"signature"`     // signature
-	Root         sql.NullString `db:"root"`          // root
+	ID                int64          `db:"id"`                  // id
+	Status            int            `db:"status"`              // status
+	BeginBlock        int64          `db:"begin_block"`         // begin_block
+	EndBlock          int64          `db:"end_block"`           // end_block
+	Parties           StringSlice    `db:"parties"`             // parties
+	Proposer          sql.NullString `db:"proposer"`            // proposer
+	OldKey            sql.NullString `db:"old_key"`             // old_key
+	NewKey            sql.NullString `db:"new_key"`             // new_key
+	KeySignature      sql.NullString `db:"key_signature"`       // key_signature
+	Signature         sql.NullString `db:"signature"`           // signature
+	Root              sql.NullString `db:"root"`                // root
+	NewEddsaKey       sql.NullString `db:"new_eddsa_key"`       // new_eddsa_key
+	EddsaKeySignature sql.NullString `db:"eddsa_key_signature"` // eddsa_key_signature
+	SignatureFailure  sql.NullString `db:"signature_failure"`   // signature_failure
+
+}
+
+// SessionSnapshot represents a row from 'public.session_snapshots'.
+type SessionSnapshot struct {
+	SessionType             int            `db:"session_type"`              // session_type
+	SessionID               int64          `db:"session_id"`                // session_id
+	Controller              int            `db:"controller"`                // controller
+	BeginBlock              int64          `db:"begin_block"`               // begin_block
+	ControllerBlock         int64          `db:"controller_block"`          // controller_block
+	Processing              bool           `db:"processing"`                // processing
+	Proposer                sql.NullString `db:"proposer"`                  // proposer
+	Indexes                 StringSlice    `db:"indexes"`                   // indexes
+	Root                    sql.NullString `db:"root"`                      // root
+	Acceptances             StringSlice    `db:"acceptances"`               // acceptances
+	Signers                 StringSlice    `db:"signers"`                   // signers
+	Offenders               StringSlice    `db:"offenders"`                 // offenders
+	IsSigner                bool           `db:"is_signer"`                 // is_signer
+	OperationSignature      sql.NullString `db:"operation_signature"`       // operation_signature
+	KeySignature            sql.NullString `db:"key_signature"`             // key_signature
+	EddsaOperationSignature sql.NullString `db:"eddsa_operation_signature"` // eddsa_operation_signature
+	EddsaKeySignature       sql.NullString `db:"eddsa_key_signature"`       // eddsa_key_signature
+	Culprits                sql.NullString `db:"culprits"`                  // culprits
 
 }
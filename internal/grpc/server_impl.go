@@ -1,10 +1,14 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	goerr "errors"
+	"io"
 	"net"
 	"net/http"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/ignite/cli/ignite/pkg/openapiconsole"
 	"github.com/rarimo/tss-svc/docs"
@@ -17,10 +21,14 @@ import (
 	"gitlab.com/distributed_lab/logan/v3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/anypb"
 )
 
+var ErrPeerNotSigner = goerr.New("request is not signed by the peer party")
+
 type ServerImpl struct {
 	types.UnimplementedServiceServer
 	manager  *core.SessionManager
@@ -30,6 +38,7 @@ type ServerImpl struct {
 	storage  secret.Storage
 	pool     *pool.Pool
 	swagger  *config.SwaggerInfo
+	params   *config.PreParamsInfo
 }
 
 func NewServer(
@@ -39,6 +48,7 @@ func NewServer(
 	storage secret.Storage,
 	pool *pool.Pool,
 	swagger *config.SwaggerInfo,
+	params *config.PreParamsInfo,
 	manager *core.SessionManager,
 ) *ServerImpl {
 	return &ServerImpl{
@@ -49,11 +59,25 @@ func NewServer(
 		storage:  storage,
 		pool:     pool,
 		swagger:  swagger,
+		params:   params,
 	}
 }
 
 func (s *ServerImpl) RunGRPC(_ context.Context) error {
-	grpcServer := grpc.NewServer()
+	opts := []grpc.ServerOption{
+		// Parties keep idle connections alive with pings, see config.ConnectionsInfo
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             config.MinKeepaliveTime,
+			PermitWithoutStream: true,
+		}),
+	}
+
+	if s.storage.GetTssSecret().MutualTLS() {
+		s.log.Info("[GRPC] Mutual TLS enabled")
+		opts = append(opts, grpc.Creds(credentials.NewTLS(secret.ServerTLSConfig(s.storage))))
+	}
+
+	grpcServer := grpc.NewServer(opts...)
 	types.RegisterServiceServer(grpcServer, s)
 	return grpcServer.Serve(s.listener)
 }
@@ -88,14 +112,56 @@ func (s *ServerImpl) RunGateway(ctx context.Context) error {
 var _ types.ServiceServer = &ServerImpl{}
 
 func (s *ServerImpl) Submit(ctx context.Context, request *types.MsgSubmitRequest) (*types.MsgSubmitResponse, error) {
+	if err := s.authPeer(ctx, request); err != nil {
+		s.log.WithError(err).Error("failed to authenticate peer")
+		// PermissionDenied is considered as rejection by the sender, so the party is not reported as offline
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
 	if err := s.manager.Receive(ctx, request); err != nil {
 		s.log.WithError(err).Error("failed to receive message")
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	return &types.MsgSubmitResponse{}, nil
 }
 
+// Stream receives the requests from the party over the long-lived stream. Frames are processed in order of receiving
+// in the same way as Submit requests and acknowledged with the status code Submit would return.
+func (s *ServerImpl) Stream(stream types.Service_StreamServer) error {
+	for {
+		frame, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		ack := &types.StreamAck{Seq: frame.Seq}
+
+		if err := s.submitFrame(stream.Context(), frame); err != nil {
+			st := status.Convert(err)
+			ack.Code = uint32(st.Code())
+			ack.Message = st.Message()
+		}
+
+		if err := stream.Send(ack); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *ServerImpl) submitFrame(ctx context.Context, frame *types.StreamFrame) error {
+	if frame.Request == nil || frame.Request.Data == nil {
+		return status.Errorf(codes.InvalidArgument, "empty stream frame")
+	}
+
+	_, err := s.Submit(ctx, frame.Request)
+	return err
+}
+
 func (s *ServerImpl) AddOperation(_ context.Context, request *types.MsgAddOperationRequest) (*types.MsgAddOperationResponse, error) {
 	err := s.pool.Add(request.Index)
 	if err != nil {
@@ -107,17 +173,16 @@ func (s *ServerImpl) AddOperation(_ context.Context, request *types.MsgAddOperat
 
 func (s *ServerImpl) Info(_ context.Context, _ *types.MsgInfoRequest) (*types.MsgInfoResponse, error) {
 	sessions := make(map[string]*types.Session)
-	sessionTypes := []types.SessionType{types.SessionType_DefaultSession, types.SessionType_ReshareSession, types.SessionType_KeygenSession}
 
-	for _, sessionType := range sessionTypes {
-		id, ok := s.manager.ID(sessionType)
+	for _, def := range core.SessionDefinitions() {
+		id, ok := s.manager.ID(def.Type)
 		if ok {
-			session, err := s.getSessionResp(sessionType, int64(id))
+			session, err := s.getSessionResp(def.Type, id)
 			if err != nil {
 				return nil, err
 			}
 
-			sessions[sessionType.String()] = session
+			sessions[def.Type.String()] = session
 		}
 	}
 
@@ -125,11 +190,22 @@ func (s *ServerImpl) Info(_ context.Context, _ *types.MsgInfoRequest) (*types.Ms
 		LocalAccount:   s.storage.GetTssSecret().AccountAddress(),
 		LocalPublicKey: s.storage.GetTssSecret().TssPubKey(),
 		Sessions:       sessions,
+		PreParamsPool:  s.getPreParamsPoolResp(),
 	}, nil
 }
 
+// getPreParamsPoolResp returns the pre-params pool status. Target is zero if pool generation is disabled.
+func (s *ServerImpl) getPreParamsPoolResp() *types.PreParamsPool {
+	pool := &types.PreParamsPool{Size: uint64(s.storage.PreParamsPoolSize())}
+	if s.params != nil {
+		pool.Target = uint64(s.params.PoolSize)
+	}
+
+	return pool
+}
+
 func (s *ServerImpl) Session(_ context.Context, request *types.MsgSessionRequest) (*types.MsgSessionResponse, error) {
-	session, err := s.getSessionResp(request.SessionType, int64(request.Id))
+	session, err := s.getSessionResp(request.SessionType, request.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -143,104 +219,51 @@ func (s *ServerImpl) Session(_ context.Context, request *types.MsgSessionRequest
 	}, nil
 }
 
-func (s *ServerImpl) getSessionResp(sessionType types.SessionType, id int64) (*types.Session, error) {
-	switch sessionType {
-	case types.SessionType_DefaultSession:
-		session, err := s.pg.DefaultSessionDatumQ().DefaultSessionDatumByID(id, false)
-		if err != nil {
-			s.log.WithError(err).Error("[GRPC] Error selecting session by id")
-			return nil, status.Error(codes.Internal, "Internal error")
-		}
-
-		if session == nil {
-			return nil, nil
-		}
-
-		details, err := anypb.New(&types.DefaultSessionData{
-			Parties:   session.Parties,
-			Proposer:  session.Proposer.String,
-			Indexes:   session.Indexes,
-			Root:      session.Root.String,
-			Accepted:  session.Accepted,
-			Signature: session.Signature.String,
-		})
-
-		if err != nil {
-			return nil, status.Error(codes.Internal, "Failed to marshal data")
-		}
-
-		return &types.Session{
-			Id:         uint64(session.ID),
-			Status:     types.SessionStatus(session.Status),
-			StartBlock: uint64(session.BeginBlock),
-			EndBlock:   uint64(session.EndBlock),
-			Type:       types.SessionType_DefaultSession,
-			Data:       details,
-		}, nil
-
-	case types.SessionType_ReshareSession:
-		session, err := s.pg.ReshareSessionDatumQ().ReshareSessionDatumByID(id, false)
-		if err != nil {
-			s.log.WithError(err).Error("[GRPC] Error selecting session by id")
-			return nil, status.Error(codes.Internal, "Internal error")
-		}
+func (s *ServerImpl) getSessionResp(sessionType types.SessionType, id uint64) (*types.Session, error) {
+	def, ok := core.GetSessionDefinition(sessionType)
+	if !ok {
+		return nil, nil
+	}
 
-		if session == nil {
-			return nil, nil
-		}
+	session, err := def.GetSession(s.pg, id)
+	if err != nil {
+		s.log.WithError(err).Error("[GRPC] Error getting session by id")
+		return nil, status.Error(codes.Internal, "Internal error")
+	}
 
-		details, err := anypb.New(&types.ReshareSessionData{
-			Parties:      session.Parties,
-			Proposer:     session.Proposer.String,
-			OldKey:       session.OldKey.String,
-			NewKey:       session.NewKey.String,
-			Root:         session.Root.String,
-			KeySignature: session.KeySignature.String,
-			Signature:    session.Signature.String,
-		})
+	return session, nil
+}
 
-		if err != nil {
-			return nil, status.Error(codes.Internal, "Failed to marshal data")
-		}
+// authPeer checks that the request has been signed by the tss key the peer TLS certificate is bound to.
+// Requests are not checked if mutual TLS is disabled.
+func (s *ServerImpl) authPeer(ctx context.Context, request *types.MsgSubmitRequest) error {
+	if !s.storage.GetTssSecret().MutualTLS() {
+		return nil
+	}
 
-		return &types.Session{
-			Id:         uint64(session.ID),
-			Status:     types.SessionStatus(session.Status),
-			StartBlock: uint64(session.BeginBlock),
-			EndBlock:   uint64(session.EndBlock),
-			Type:       types.SessionType_ReshareSession,
-			Data:       details,
-		}, nil
-
-	case types.SessionType_KeygenSession:
-		session, err := s.pg.KeygenSessionDatumQ().KeygenSessionDatumByID(id, false)
-		if err != nil {
-			s.log.WithError(err).Error("[GRPC] Error selecting session data by id")
-			return nil, status.Error(codes.Internal, "Internal error")
-		}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return secret.ErrNoPeerCertificate
+	}
 
-		if session == nil {
-			return nil, nil
-		}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return secret.ErrNoPeerCertificate
+	}
 
-		details, err := anypb.New(&types.KeygenSessionData{
-			Parties: session.Parties,
-			Key:     session.Key.String,
-		})
+	pubKey, err := secret.PeerTssPubKey(info.State.PeerCertificates[0])
+	if err != nil {
+		return err
+	}
 
-		if err != nil {
-			return nil, status.Error(codes.Internal, "Failed to marshal data")
-		}
+	signer, err := core.RecoverSigner(request)
+	if err != nil {
+		return err
+	}
 
-		return &types.Session{
-			Id:         uint64(session.ID),
-			Status:     types.SessionStatus(session.Status),
-			StartBlock: uint64(session.BeginBlock),
-			EndBlock:   uint64(session.EndBlock),
-			Type:       types.SessionType_ReshareSession,
-			Data:       details,
-		}, nil
+	if !bytes.Equal(hexutil.MustDecode(pubKey), signer) {
+		return ErrPeerNotSigner
 	}
 
-	return nil, nil
+	return nil
 }
@@ -28,6 +28,9 @@ type Pool struct {
 	// but without actual information about signed status.
 	rawOrder chan string
 	index    map[string]struct{}
+	// inFlight stores the indexes that are being signed in the active sessions by session id.
+	// Such indexes are not returned by GetNext, so pipelined sessions do not sign the same operations twice.
+	inFlight map[string]uint64
 }
 
 func NewPool(cfg config.Config) *Pool {
@@ -36,6 +39,7 @@ func NewPool(cfg config.Config) *Pool {
 		log:      cfg.Log(),
 		rawOrder: make(chan string, poolSz),
 		index:    make(map[string]struct{}),
+		inFlight: make(map[string]uint64),
 	}
 }
 
@@ -67,6 +71,11 @@ func (p *Pool) GetNext(n uint) ([]string, error) {
 	for collected < n {
 		select {
 		case id := <-p.rawOrder:
+			if _, ok := p.inFlight[id]; ok {
+				delete(p.index, id)
+				continue
+			}
+
 			err := p.checkStatus(id)
 			switch err {
 			case ErrOpShouldBeApproved:
@@ -89,6 +98,38 @@ func (p *Pool) GetNext(n uint) ([]string, error) {
 	return res, nil
 }
 
+// Reserve marks the indexes as being signed in the session with provided id.
+// Returns false if any of the indexes is already being signed in another session.
+func (p *Pool) Reserve(session uint64, ids []string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, id := range ids {
+		if reserved, ok := p.inFlight[id]; ok && reserved != session {
+			return false
+		}
+	}
+
+	for _, id := range ids {
+		p.inFlight[id] = session
+	}
+
+	return true
+}
+
+// Release removes the indexes reservation of the session with provided id.
+// Should be called before returning the indexes of the failed session back to the pool.
+func (p *Pool) Release(session uint64, ids []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, id := range ids {
+		if reserved, ok := p.inFlight[id]; ok && reserved == session {
+			delete(p.inFlight, id)
+		}
+	}
+}
+
 func (p *Pool) checkStatus(id string) error {
 	resp, err := rarimo.NewQueryClient(p.rarimo).Operation(context.TODO(), &rarimo.QueryGetOperationRequest{Index: id})
 	if err != nil {
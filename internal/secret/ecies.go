@@ -0,0 +1,70 @@
+package secret
+
+import (
+	"crypto/rand"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+// Encrypt encrypts the data for the party with provided hex public key (uncompressed point without 0x04 prefix)
+// using ECIES over secp256k1. The static ECDH secret of sender and receiver keys is used as the KDF shared information,
+// so only the owner of the sender key can produce the ciphertext accepted by the receiver.
+func (t *TssSecret) Encrypt(receiverPubKey string, data []byte) ([]byte, error) {
+	pub, err := parsePubKey(receiverPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := t.sharedInfo(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return ecies.Encrypt(rand.Reader, pub, data, shared, nil)
+}
+
+// Decrypt decrypts the data encrypted by the party with provided hex public key using Encrypt.
+func (t *TssSecret) Decrypt(senderPubKey string, data []byte) ([]byte, error) {
+	pub, err := parsePubKey(senderPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := t.sharedInfo(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return ecies.ImportECDSA(t.tssPrv).Decrypt(data, shared, nil)
+}
+
+func (t *TssSecret) sharedInfo(pub *ecies.PublicKey) ([]byte, error) {
+	if t.tssPrv == nil {
+		return nil, ErrUninitializedPrivateKey
+	}
+
+	shared, err := ecies.ImportECDSA(t.tssPrv).GenerateShared(pub, ecies.ECIES_AES128_SHA256.KeyLen, ecies.ECIES_AES128_SHA256.KeyLen)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate shared secret")
+	}
+
+	return shared, nil
+}
+
+func parsePubKey(pubKey string) (*ecies.PublicKey, error) {
+	raw, err := hexutil.Decode(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode public key")
+	}
+
+	// Parties public keys do not contain constant 0x04 first byte
+	pub, err := eth.UnmarshalPubkey(append([]byte{4}, raw...))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal public key")
+	}
+
+	return ecies.ImportECDSAPublic(pub), nil
+}
@@ -0,0 +1,83 @@
+package secret
+
+import (
+	"context"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/rarimo/tss-svc/internal/config"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+// PreParamsGenerator keeps the pool of fresh validated pre-params in the secret storage.
+// Every keygen or reshare consumes one set from the pool, so the generator refills it in background
+// to avoid generating pre-params (that can take several minutes) in the middle of the session.
+type PreParamsGenerator struct {
+	storage Storage
+	info    *config.PreParamsInfo
+	log     *logan.Entry
+}
+
+// NewPreParamsGenerator creates the generator that refills the storage pre-params pool up to the configured size.
+func NewPreParamsGenerator(storage Storage, info *config.PreParamsInfo, log *logan.Entry) *PreParamsGenerator {
+	return &PreParamsGenerator{
+		storage: storage,
+		info:    info,
+		log:     log,
+	}
+}
+
+func (g *PreParamsGenerator) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.info.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		g.fill(ctx)
+
+		select {
+		case <-ctx.Done():
+			g.log.Info("Context finished")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (g *PreParamsGenerator) fill(ctx context.Context) {
+	for g.storage.PreParamsPoolSize() < g.info.PoolSize {
+		if ctx.Err() != nil {
+			return
+		}
+
+		g.log.Infof("[PreParams] Generating pre-params for the pool size=%d", g.storage.PreParamsPoolSize())
+
+		params, err := g.generate(ctx)
+		if err != nil {
+			g.log.WithError(err).Error("[PreParams] Failed to generate pre-params")
+			return
+		}
+
+		if err := g.storage.AddPreParams(params); err != nil {
+			g.log.WithError(err).Error("[PreParams] Failed to add pre-params to the pool")
+			return
+		}
+
+		g.log.Info("[PreParams] New pre-params added to the pool")
+	}
+}
+
+func (g *PreParamsGenerator) generate(ctx context.Context) (*keygen.LocalPreParams, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.info.Timeout)
+	defer cancel()
+
+	params, err := keygen.GeneratePreParamsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !params.ValidateWithProof() {
+		return nil, ErrInvalidPreParams
+	}
+
+	return params, nil
+}
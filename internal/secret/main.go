@@ -8,7 +8,11 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	tsskeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	tssresharing "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
 	tsssign "github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	eddsaresharing "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	eddsasign "github.com/bnb-chain/tss-lib/v2/eddsa/signing"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	"github.com/cosmos/cosmos-sdk/client"
 	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
@@ -17,6 +21,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	xauthsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth "github.com/ethereum/go-ethereum/crypto"
 	"github.com/rarimo/tss-svc/pkg/types"
@@ -26,14 +31,23 @@ import (
 var (
 	ErrUninitializedPrivateKey = goerr.New("private key or TSS data should be initialized")
 	ErrNoTssData               = goerr.New("tss data is empty")
+	ErrNoEdDSAData             = goerr.New("eddsa tss data is empty")
+	ErrInvalidPreParams        = goerr.New("pre-params validation failed")
 )
 
 type TssSecret struct {
 	tssPrv     *ecdsa.PrivateKey
 	accountPrv cryptotypes.PrivKey
 	data       *tsskeygen.LocalPartySaveData
+	eddsaData  *eddsakeygen.LocalPartySaveData
 	params     *tsskeygen.LocalPreParams
 	tls        bool
+	// tlsKey is used for mutual TLS between parties (see TLSCertificate)
+	tlsKey *ecdsa.PrivateKey
+	// generation is incremented on every resharing of the ECDSA key (see core.GetTssShareKey)
+	generation uint64
+	// eddsaGeneration is incremented on every resharing of the EdDSA key
+	eddsaGeneration uint64
 }
 
 func NewTssSecret(prv *ecdsa.PrivateKey, account cryptotypes.PrivKey, data *tsskeygen.LocalPartySaveData, params *tsskeygen.LocalPreParams, tls bool) *TssSecret {
@@ -58,6 +72,8 @@ func NewTssSecret(prv *ecdsa.PrivateKey, account cryptotypes.PrivKey, data *tssk
 	}
 }
 
+// NewWithData returns the copy of the secret with provided ECDSA key share. The key share becomes the party tss key.
+// EdDSA key share and generations are kept.
 func (t *TssSecret) NewWithData(data *tsskeygen.LocalPartySaveData) *TssSecret {
 	prv, err := eth.ToECDSA(data.Xi.Bytes())
 	if err != nil {
@@ -65,14 +81,74 @@ func (t *TssSecret) NewWithData(data *tsskeygen.LocalPartySaveData) *TssSecret {
 	}
 
 	return &TssSecret{
-		tssPrv:     prv,
-		accountPrv: t.accountPrv,
-		data:       data,
-		params:     &data.LocalPreParams,
+		tssPrv:          prv,
+		accountPrv:      t.accountPrv,
+		data:            data,
+		eddsaData:       t.eddsaData,
+		params:          &data.LocalPreParams,
+		tls:             t.tls,
+		tlsKey:          t.tlsKey,
+		generation:      t.generation,
+		eddsaGeneration: t.eddsaGeneration,
 	}
 }
 
+// NewWithResharedData returns the copy of the secret with provided key share of the resharing generation.
+func (t *TssSecret) NewWithResharedData(data *tsskeygen.LocalPartySaveData, generation uint64) *TssSecret {
+	secret := t.NewWithData(data)
+	secret.generation = generation
+	return secret
+}
+
+// NewWithEdDSAData returns the copy of the secret with provided EdDSA key share.
+func (t *TssSecret) NewWithEdDSAData(data *eddsakeygen.LocalPartySaveData) *TssSecret {
+	return &TssSecret{
+		tssPrv:          t.tssPrv,
+		accountPrv:      t.accountPrv,
+		data:            t.data,
+		eddsaData:       data,
+		params:          t.params,
+		tls:             t.tls,
+		tlsKey:          t.tlsKey,
+		generation:      t.generation,
+		eddsaGeneration: t.eddsaGeneration,
+	}
+}
+
+// NewWithResharedEdDSAData returns the copy of the secret with provided EdDSA key share of the resharing generation.
+func (t *TssSecret) NewWithResharedEdDSAData(data *eddsakeygen.LocalPartySaveData, generation uint64) *TssSecret {
+	secret := t.NewWithEdDSAData(data)
+	secret.eddsaGeneration = generation
+	return secret
+}
+
+// NewWithGeneration returns the copy of the secret with provided key share generation.
+func (t *TssSecret) NewWithGeneration(generation uint64) *TssSecret {
+	secret := t.NewWithEdDSAData(t.eddsaData)
+	secret.generation = generation
+	return secret
+}
+
+// NewWithPreParams returns the copy of the secret with provided pre-params that will be used for the new key share.
+func (t *TssSecret) NewWithPreParams(params *tsskeygen.LocalPreParams) *TssSecret {
+	secret := t.NewWithEdDSAData(t.eddsaData)
+	secret.params = params
+	return secret
+}
+
+// NewWithTLSKey returns the copy of the secret with provided mutual TLS key.
+func (t *TssSecret) NewWithTLSKey(key *ecdsa.PrivateKey) *TssSecret {
+	secret := t.NewWithEdDSAData(t.eddsaData)
+	secret.tlsKey = key
+	return secret
+}
+
 func (t *TssSecret) Sign(request *types.MsgSubmitRequest) error {
+	// Observer secret does not contain any keys
+	if t.tssPrv == nil {
+		return ErrUninitializedPrivateKey
+	}
+
 	details, err := anypb.New(request.Data)
 	if err != nil {
 		return err
@@ -89,6 +165,10 @@ func (t *TssSecret) Sign(request *types.MsgSubmitRequest) error {
 }
 
 func (t *TssSecret) SignTransaction(txConfig client.TxConfig, data xauthsigning.SignerData, builder client.TxBuilder, account *authtypes.BaseAccount) (signing.SignatureV2, error) {
+	if t.accountPrv == nil {
+		return signing.SignatureV2{}, ErrUninitializedPrivateKey
+	}
+
 	return clienttx.SignWithPrivKey(
 		txConfig.SignModeHandler().DefaultMode(), data,
 		builder, t.accountPrv, txConfig, account.Sequence,
@@ -96,12 +176,21 @@ func (t *TssSecret) SignTransaction(txConfig client.TxConfig, data xauthsigning.
 }
 
 func (t *TssSecret) TssPubKey() string {
+	// Observer secret does not contain any keys
+	if t.tssPrv == nil {
+		return ""
+	}
+
 	// Marshalled point contains constant 0x04 first byte, we have to remove it
 	marshalled := elliptic.Marshal(eth.S256(), t.tssPrv.X, t.tssPrv.Y)
 	return hexutil.Encode(marshalled[1:])
 }
 
 func (t *TssSecret) AccountAddress() string {
+	if t.accountPrv == nil {
+		return ""
+	}
+
 	address, _ := bech32.ConvertAndEncode(AccountPrefix, t.accountPrv.PubKey().Address().Bytes())
 	return address
 }
@@ -111,7 +200,7 @@ func (t *TssSecret) AccountPubKey() cryptotypes.PubKey {
 }
 
 func (t *TssSecret) GlobalPubKey() string {
-	if t.data == nil {
+	if t.data == nil || t.data.ECDSAPub == nil {
 		return ""
 	}
 
@@ -120,7 +209,32 @@ func (t *TssSecret) GlobalPubKey() string {
 	return hexutil.Encode(marshalled[1:])
 }
 
+// GlobalEdDSAPubKey returns the hex-encoded Ed25519 public key (compressed 32 bytes)
+// or empty string if EdDSA key share is not generated yet.
+func (t *TssSecret) GlobalEdDSAPubKey() string {
+	if t.eddsaData == nil || t.eddsaData.EDDSAPub == nil {
+		return ""
+	}
+
+	return hexutil.Encode(edwards.NewPublicKey(t.eddsaData.EDDSAPub.X(), t.eddsaData.EDDSAPub.Y()).SerializeCompressed())
+}
+
+// Generation returns the resharing generation of the ECDSA key share.
+func (t *TssSecret) Generation() uint64 {
+	return t.generation
+}
+
+// EdDSAGeneration returns the resharing generation of the EdDSA key share.
+func (t *TssSecret) EdDSAGeneration() uint64 {
+	return t.eddsaGeneration
+}
+
+// GetKeygenParty returns the keygen party. If the secret has no pre-params, the party generates them in the first round.
 func (t *TssSecret) GetKeygenParty(params *tss.Parameters, out chan<- tss.Message, end chan<- *tsskeygen.LocalPartySaveData) tss.Party {
+	if t.params == nil {
+		return tsskeygen.NewLocalParty(params, out, end)
+	}
+
 	return tsskeygen.NewLocalParty(params, out, end, *t.params)
 }
 
@@ -128,6 +242,48 @@ func (t *TssSecret) GetSignParty(msg *big.Int, params *tss.Parameters, out chan<
 	return tsssign.NewLocalParty(msg, params, *t.data, out, end)
 }
 
+// GetReshareParty returns the resharing party of the old committee that distributes the current key share.
+// The key share is copied, because the old committee party erases the share after distribution
+// while the current share can still be used for signing if resharing fails.
+func (t *TssSecret) GetReshareParty(params *tss.ReSharingParameters, out chan<- tss.Message, end chan<- *tsskeygen.LocalPartySaveData) tss.Party {
+	key := *t.data
+	key.Xi = new(big.Int).Set(t.data.Xi)
+	return tssresharing.NewLocalParty(params, key, out, end)
+}
+
+// GetNewCommitteeReshareParty returns the resharing party of the new committee that receives the new key share.
+// If the secret has no pre-params, the party generates them during resharing.
+func (t *TssSecret) GetNewCommitteeReshareParty(params *tss.ReSharingParameters, out chan<- tss.Message, end chan<- *tsskeygen.LocalPartySaveData) tss.Party {
+	save := tsskeygen.NewLocalPartySaveData(params.NewPartyCount())
+	if t.params != nil {
+		save.LocalPreParams = *t.params
+	}
+	return tssresharing.NewLocalParty(params, save, out, end)
+}
+
+func (t *TssSecret) GetEdDSAKeygenParty(params *tss.Parameters, out chan<- tss.Message, end chan<- *eddsakeygen.LocalPartySaveData) tss.Party {
+	return eddsakeygen.NewLocalParty(params, out, end)
+}
+
+// GetEdDSAReshareParty returns the EdDSA resharing party of the old committee that distributes the current key share.
+// The key share is copied, because the old committee party erases the share after distribution.
+func (t *TssSecret) GetEdDSAReshareParty(params *tss.ReSharingParameters, out chan<- tss.Message, end chan<- *eddsakeygen.LocalPartySaveData) tss.Party {
+	key := *t.eddsaData
+	key.Xi = new(big.Int).Set(t.eddsaData.Xi)
+	return eddsaresharing.NewLocalParty(params, key, out, end)
+}
+
+// GetNewCommitteeEdDSAReshareParty returns the EdDSA resharing party of the new committee that receives the new key share.
+func (t *TssSecret) GetNewCommitteeEdDSAReshareParty(params *tss.ReSharingParameters, out chan<- tss.Message, end chan<- *eddsakeygen.LocalPartySaveData) tss.Party {
+	return eddsaresharing.NewLocalParty(params, eddsakeygen.NewLocalPartySaveData(params.NewPartyCount()), out, end)
+}
+
+// GetEdDSASignParty returns the EdDSA signing party. The party signs the big-endian bytes of msg,
+// so the leading zero bytes of the message should be kept by the caller (see tss.EdDSAMessage).
+func (t *TssSecret) GetEdDSASignParty(msg *big.Int, params *tss.Parameters, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+	return eddsasign.NewLocalParty(msg, params, *t.eddsaData, out, end)
+}
+
 func (t *TssSecret) TLS() bool {
 	return t.tls
 }
@@ -136,4 +292,11 @@ func (t *TssSecret) TLS() bool {
 type Storage interface {
 	GetTssSecret() *TssSecret
 	SetTssSecret(secret *TssSecret) error
+
+	// PreParamsPoolSize returns the amount of fresh pre-params available in the pool
+	PreParamsPoolSize() int
+	// AddPreParams puts the validated pre-params to the pool
+	AddPreParams(params *tsskeygen.LocalPreParams) error
+	// TakePreParams removes the pre-params from the pool and returns them. Returns nil if the pool is empty.
+	TakePreParams() (*tsskeygen.LocalPreParams, error)
 }
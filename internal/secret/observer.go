@@ -0,0 +1,42 @@
+package secret
+
+import (
+	goerr "errors"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+)
+
+var ErrObserverStorage = goerr.New("observer storage does not store TSS secret")
+
+// ObserverStorage is the storage for the observer service that does not hold account or TSS keys.
+// It returns the empty secret with blank account address and public keys.
+type ObserverStorage struct {
+	secret *TssSecret
+}
+
+func NewObserverStorage() *ObserverStorage {
+	return &ObserverStorage{secret: &TssSecret{}}
+}
+
+// Implements Storage interface
+var _ Storage = &ObserverStorage{}
+
+func (o *ObserverStorage) GetTssSecret() *TssSecret {
+	return o.secret
+}
+
+func (o *ObserverStorage) SetTssSecret(*TssSecret) error {
+	return ErrObserverStorage
+}
+
+func (o *ObserverStorage) PreParamsPoolSize() int {
+	return 0
+}
+
+func (o *ObserverStorage) AddPreParams(*keygen.LocalPreParams) error {
+	return ErrObserverStorage
+}
+
+func (o *ObserverStorage) TakePreParams() (*keygen.LocalPreParams, error) {
+	return nil, nil
+}
@@ -0,0 +1,239 @@
+package secret
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	goerr "errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/crypto"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+var (
+	ErrNoPeerCertificate    = goerr.New("peer certificate is not provided")
+	ErrNoPartyBinding       = goerr.New("peer certificate does not contain party key binding")
+	ErrPeerKeyMismatch      = goerr.New("peer certificate is bound to another party key")
+	ErrUnsupportedTLSKeyPEM = goerr.New("tls key should be PEM encoded ECDSA private key")
+)
+
+// partyBindingOID identifies the certificate extension that contains the tss key signature of the certificate public key
+var partyBindingOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 59112, 1, 1}
+
+// tlsCertificateValidity is the validity period of the generated certificates. Certificates are never verified by
+// the chain of trust, so the validity only matters for the peer TLS implementation.
+const tlsCertificateValidity = 24 * time.Hour
+
+// certificateCache keeps the certificate of the current tss and TLS keys, so the tss key signature is not produced
+// on every handshake. Certificate is renewed after the half of its validity period or after the keys change.
+type certificateCache struct {
+	mu      sync.Mutex
+	keys    string
+	cert    *tls.Certificate
+	renewAt time.Time
+}
+
+func (c *certificateCache) get(t *TssSecret) (*tls.Certificate, error) {
+	if t.tlsKey == nil || t.tssPrv == nil {
+		return nil, ErrUninitializedPrivateKey
+	}
+
+	spki, err := x509.MarshalPKIXPublicKey(&t.tlsKey.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal tls public key")
+	}
+
+	keys := t.TssPubKey() + hexutil.Encode(spki)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cert != nil && c.keys == keys && time.Now().Before(c.renewAt) {
+		return c.cert, nil
+	}
+
+	cert, err := t.TLSCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	c.keys = keys
+	c.cert = cert
+	c.renewAt = time.Now().Add(tlsCertificateValidity / 2)
+	return cert, nil
+}
+
+// MutualTLS returns true if the party TLS key is configured, so connections between parties should use mutual TLS.
+func (t *TssSecret) MutualTLS() bool {
+	return t.tlsKey != nil
+}
+
+// TLSCertificate returns the self-signed certificate of the party TLS key. The certificate contains the extension with
+// the tss key signature of the certificate public key, so the peer can bind the connection to the on-chain party
+// public key (see PeerTssPubKey). The binding follows the tss key changes after key generation and resharing.
+func (t *TssSecret) TLSCertificate() (*tls.Certificate, error) {
+	if t.tlsKey == nil || t.tssPrv == nil {
+		return nil, ErrUninitializedPrivateKey
+	}
+
+	spki, err := x509.MarshalPKIXPublicKey(&t.tlsKey.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal tls public key")
+	}
+
+	binding, err := eth.Sign(eth.Keccak256(spki), t.tssPrv)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign tls public key")
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate certificate serial number")
+	}
+
+	now := time.Now().UTC()
+	template := &x509.Certificate{
+		SerialNumber:    serial,
+		Subject:         pkix.Name{CommonName: t.TssPubKey()},
+		NotBefore:       now.Add(-time.Hour),
+		NotAfter:        now.Add(tlsCertificateValidity),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		ExtraExtensions: []pkix.Extension{{Id: partyBindingOID, Value: binding}},
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &t.tlsKey.PublicKey, t.tlsKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create certificate")
+	}
+
+	return &tls.Certificate{
+		Certificate: [][]byte{raw},
+		PrivateKey:  t.tlsKey,
+	}, nil
+}
+
+// ClientTLSConfig returns the mutual TLS configuration to connect to the party with provided hex tss public key.
+// The chain of trust is not verified: the server is authenticated by the party key binding of its certificate.
+func (t *TssSecret) ClientTLSConfig(pubKey string) *tls.Config {
+	cache := &certificateCache{}
+
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS13,
+		InsecureSkipVerify: true,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return cache.get(t)
+		},
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			peer, err := peerTssPubKey(rawCerts)
+			if err != nil {
+				return err
+			}
+
+			expected, err := hexutil.Decode(pubKey)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode party public key")
+			}
+
+			if !bytes.Equal(hexutil.MustDecode(peer), expected) {
+				return ErrPeerKeyMismatch
+			}
+
+			return nil
+		},
+	}
+}
+
+// ServerTLSConfig returns the mutual TLS configuration for the party server. Provided client certificates should be
+// bound to some tss key: the server should check that the party requests are signed by the same key.
+// Client certificates are optional to keep the public endpoints available for non-party clients.
+// The current storage secret is used on every handshake to follow the tss key changes, the certificate
+// is generated only after the key changes (see certificateCache).
+func ServerTLSConfig(storage Storage) *tls.Config {
+	cache := &certificateCache{}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		ClientAuth: tls.RequestClientCert,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return cache.get(storage.GetTssSecret())
+		},
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return nil
+			}
+
+			_, err := peerTssPubKey(rawCerts)
+			return err
+		},
+	}
+}
+
+// PeerTssPubKey returns the hex tss public key (uncompressed point without 0x04 prefix) the certificate is bound to.
+func PeerTssPubKey(cert *x509.Certificate) (string, error) {
+	var binding []byte
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(partyBindingOID) {
+			binding = ext.Value
+			break
+		}
+	}
+
+	if binding == nil {
+		return "", ErrNoPartyBinding
+	}
+
+	pub, err := eth.Ecrecover(eth.Keccak256(cert.RawSubjectPublicKeyInfo), binding)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to recover party key")
+	}
+
+	// Recovered key contains constant 0x04 first byte
+	return hexutil.Encode(pub[1:]), nil
+}
+
+func peerTssPubKey(rawCerts [][]byte) (string, error) {
+	if len(rawCerts) == 0 {
+		return "", ErrNoPeerCertificate
+	}
+
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse peer certificate")
+	}
+
+	return PeerTssPubKey(cert)
+}
+
+// ParseTLSKey parses the PEM encoded (PKCS #8 or SEC 1) ECDSA private key.
+func ParseTLSKey(data string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, ErrUnsupportedTLSKeyPEM
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse tls key")
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrUnsupportedTLSKeyPEM
+	}
+
+	return ecdsaKey, nil
+}
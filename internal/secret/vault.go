@@ -5,9 +5,11 @@ import (
 	"crypto/ecdsa"
 	"encoding/json"
 	"os"
+	"strconv"
 	"sync"
 
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -19,16 +21,23 @@ import (
 
 const (
 	dataKey    = "data"
+	eddsaKey   = "eddsa"
+	genKey     = "generation"
+	eddsaGen   = "eddsa_generation"
 	preKey     = "pre"
+	poolKey    = "pre_pool"
 	accountKey = "account"
 	trialKey   = "trial"
 	enableTLS  = "tls"
+	tlsKey     = "tls_key"
 )
 
 type VaultStorage struct {
 	once     sync.Once
+	mu       sync.Mutex
 	log      *logan.Entry
 	secret   *TssSecret
+	pool     []*keygen.LocalPreParams
 	kvSecret *vault.KVSecret
 	client   *vault.KVv2
 	path     string
@@ -46,18 +55,15 @@ func NewVaultStorage(cfg config.Config) *VaultStorage {
 var _ Storage = &VaultStorage{}
 
 func (v *VaultStorage) GetTssSecret() *TssSecret {
-	v.once.Do(func() {
-		var err error
-		v.secret, err = v.loadSecret()
-		if err != nil {
-			panic(err)
-		}
-	})
-
+	v.load()
 	return v.secret
 }
 
 func (v *VaultStorage) SetTssSecret(secret *TssSecret) error {
+	v.load()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
 	v.secret = secret
 
 	dataJson, err := json.Marshal(secret.data)
@@ -71,13 +77,87 @@ func (v *VaultStorage) SetTssSecret(secret *TssSecret) error {
 	}
 
 	v.kvSecret.Data[dataKey] = string(dataJson)
+
+	// EdDSA data can be empty if EdDSA keygen has not been finished
+	v.kvSecret.Data[eddsaKey] = ""
+	if secret.eddsaData != nil {
+		eddsaJson, err := json.Marshal(secret.eddsaData)
+		if err != nil {
+			return err
+		}
+
+		v.kvSecret.Data[eddsaKey] = string(eddsaJson)
+	}
+
+	v.kvSecret.Data[genKey] = strconv.FormatUint(secret.generation, 10)
+	v.kvSecret.Data[eddsaGen] = strconv.FormatUint(secret.eddsaGeneration, 10)
 	v.kvSecret.Data[preKey] = string(preJson)
-	// Account and Trial Private Key will not be changed by tss instance, so - skipped
+	// Account, Trial Private Key and TLS key will not be changed by tss instance, so - skipped
 
 	v.kvSecret, err = v.client.Put(context.TODO(), v.path, v.kvSecret.Data)
 	return err
 }
 
+func (v *VaultStorage) PreParamsPoolSize() int {
+	v.load()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return len(v.pool)
+}
+
+func (v *VaultStorage) AddPreParams(params *keygen.LocalPreParams) error {
+	v.load()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.savePool(append(v.pool, params))
+}
+
+func (v *VaultStorage) TakePreParams() (*keygen.LocalPreParams, error) {
+	v.load()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.pool) == 0 {
+		return nil, nil
+	}
+
+	// Removing pre-params from the storage before usage to never use the same set twice
+	params := v.pool[0]
+	if err := v.savePool(v.pool[1:]); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// savePool should be called under the lock
+func (v *VaultStorage) savePool(pool []*keygen.LocalPreParams) error {
+	poolJson, err := json.Marshal(pool)
+	if err != nil {
+		return err
+	}
+
+	v.kvSecret.Data[poolKey] = string(poolJson)
+
+	v.kvSecret, err = v.client.Put(context.TODO(), v.path, v.kvSecret.Data)
+	if err != nil {
+		return errors.Wrap(err, "failed to save pre-params pool")
+	}
+
+	v.pool = pool
+	return nil
+}
+
+func (v *VaultStorage) load() {
+	v.once.Do(func() {
+		var err error
+		v.secret, err = v.loadSecret()
+		if err != nil {
+			panic(err)
+		}
+	})
+}
+
 func (v *VaultStorage) loadSecret() (*TssSecret, error) {
 	var err error
 	v.kvSecret, err = v.client.Get(context.Background(), v.path)
@@ -92,10 +172,41 @@ func (v *VaultStorage) loadSecret() (*TssSecret, error) {
 		v.log.Info("[Vault] TSS Save Data is empty")
 	}
 
+	// EdDSA data can be absent
+	var eddsaData *eddsakeygen.LocalPartySaveData
+	if eddsaJson, ok := v.kvSecret.Data[eddsaKey].(string); ok && eddsaJson != "" {
+		eddsaData = new(eddsakeygen.LocalPartySaveData)
+		if err := json.Unmarshal([]byte(eddsaJson), eddsaData); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal eddsa data")
+		}
+	}
+
+	// Generation is absent for the keys that have never been reshared
+	var generation uint64
+	if genStr, ok := v.kvSecret.Data[genKey].(string); ok && genStr != "" {
+		if generation, err = strconv.ParseUint(genStr, 10, 64); err != nil {
+			return nil, errors.Wrap(err, "failed to parse key generation")
+		}
+	}
+
+	// EdDSA generation is absent for the EdDSA keys that have never been reshared
+	var eddsaGeneration uint64
+	if genStr, ok := v.kvSecret.Data[eddsaGen].(string); ok && genStr != "" {
+		if eddsaGeneration, err = strconv.ParseUint(genStr, 10, 64); err != nil {
+			return nil, errors.Wrap(err, "failed to parse eddsa key generation")
+		}
+	}
+
+	// Pre-params pool can be absent
+	if poolJson, ok := v.kvSecret.Data[poolKey].(string); ok && poolJson != "" {
+		if err := json.Unmarshal([]byte(poolJson), &v.pool); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal pre-params pool")
+		}
+	}
+
 	pre := new(keygen.LocalPreParams)
 	if err := json.Unmarshal([]byte(v.kvSecret.Data[preKey].(string)), pre); err != nil {
-		v.log.Info("[Vault] Generating tss pre-params")
-		pre = loadParams()
+		pre = v.takeInitialParams()
 	}
 
 	if !pre.ValidateWithProof() {
@@ -117,5 +228,38 @@ func (v *VaultStorage) loadSecret() (*TssSecret, error) {
 		tls = enableI.(bool)
 	}
 
-	return NewTssSecret(prv, account, data, pre, tls), nil
+	// Mutual TLS key is optional
+	var tlsPrv *ecdsa.PrivateKey
+	if tlsPem, ok := v.kvSecret.Data[tlsKey].(string); ok && tlsPem != "" {
+		if tlsPrv, err = ParseTLSKey(tlsPem); err != nil {
+			return nil, errors.Wrap(err, "failed to parse mutual tls key")
+		}
+	}
+
+	return NewTssSecret(prv, account, data, pre, tls).NewWithResharedEdDSAData(eddsaData, eddsaGeneration).NewWithGeneration(generation).NewWithTLSKey(tlsPrv), nil
+}
+
+// takeInitialParams returns the pre-params from the pool or generates the new ones if pool is empty.
+func (v *VaultStorage) takeInitialParams() *keygen.LocalPreParams {
+	if len(v.pool) > 0 {
+		v.log.Info("[Vault] Using tss pre-params from the pool")
+		pre := v.pool[0]
+
+		preJson, err := json.Marshal(pre)
+		if err != nil {
+			panic(err)
+		}
+
+		// Pre-params should be stored as current ones to prevent taking them from the pool after restart
+		v.kvSecret.Data[preKey] = string(preJson)
+		if err := v.savePool(v.pool[1:]); err != nil {
+			v.log.WithError(err).Error("[Vault] Failed to save pre-params taken from the pool")
+			v.pool = v.pool[1:]
+		}
+
+		return pre
+	}
+
+	v.log.Info("[Vault] Generating tss pre-params")
+	return loadParams()
 }
@@ -2,18 +2,37 @@ package timer
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/tendermint/tendermint/rpc/client/http"
 	"gitlab.com/distributed_lab/logan/v3"
 )
 
 type BlockNotifier func(height uint64) error
 
+var (
+	notificationLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tss_timer_notification_lag_blocks",
+		Help: "Difference between the current block and the last block delivered to the subscriber",
+	}, []string{"subscriber"})
+
+	notificationDelay = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tss_timer_notification_delay_seconds",
+		Help:    "Time between receiving the block and finishing the subscriber notification",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"subscriber"})
+)
+
 // Timer provides the source for timestamping all operations in the tss system.
-// Use Notifier to receive notification about new blocks in your service
+// Use Notifier to receive notification about new blocks in your service.
+// Every subscriber receives all blocks strictly in order, the next notification starts only after the previous one finished.
 type Timer struct {
+	mu           sync.RWMutex
 	currentBlock uint64
-	toNotify     map[string]BlockNotifier
+	toNotify     map[string]*subscriber
 	log          *logan.Entry
 }
 
@@ -25,35 +44,125 @@ func NewTimer(tendermint *http.HTTP, log *logan.Entry) *Timer {
 
 	return &Timer{
 		currentBlock: uint64(info.SyncInfo.LatestBlockHeight),
-		toNotify:     make(map[string]BlockNotifier),
+		toNotify:     make(map[string]*subscriber),
 		log:          log,
 	}
 }
 
 // Only for internal usage in block subscriber
 func (t *Timer) newBlock(height uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	t.currentBlock = height
-	go t.notifyAll(height)
+	for _, s := range t.toNotify {
+		s.push(height)
+	}
 }
 
 func (t *Timer) CurrentBlock() uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.currentBlock
 }
 
-// SubscribeToBlocks adds receiver method to notify fot the new block events
+// SubscribeToBlocks adds receiver method to notify fot the new block events starting from the current block.
+// Subscribing with the already used name replaces the previous receiver.
 func (t *Timer) SubscribeToBlocks(name string, f BlockNotifier) {
-	t.toNotify[name] = f
-	go t.notify(t.currentBlock, name, f)
-}
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-func (t *Timer) notifyAll(height uint64) {
-	for name, f := range t.toNotify {
-		t.notify(height, name, f)
+	if s, ok := t.toNotify[name]; ok {
+		s.stop()
 	}
+
+	s := newSubscriber(name, f)
+	t.toNotify[name] = s
+	go s.run(t)
+	s.push(t.currentBlock)
 }
 
-func (t *Timer) notify(height uint64, name string, f BlockNotifier) {
+func (t *Timer) notify(height uint64, received time.Time, name string, f BlockNotifier) {
 	if err := f(height); err != nil {
 		t.log.WithError(err).Errorf("[Block] Got an error notifying for the new block %s", name)
 	}
+
+	notificationDelay.WithLabelValues(name).Observe(time.Since(received).Seconds())
+	notificationLag.WithLabelValues(name).Set(float64(t.CurrentBlock() - height))
+}
+
+type block struct {
+	height   uint64
+	received time.Time
+}
+
+// subscriber holds the queue of blocks to be delivered to the receiver in order
+type subscriber struct {
+	mu      sync.Mutex
+	name    string
+	f       BlockNotifier
+	queue   []block
+	signal  chan struct{}
+	stopped bool
+}
+
+func newSubscriber(name string, f BlockNotifier) *subscriber {
+	return &subscriber{
+		name:   name,
+		f:      f,
+		queue:  make([]block, 0),
+		signal: make(chan struct{}, 1),
+	}
+}
+
+func (s *subscriber) push(height uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+
+	s.queue = append(s.queue, block{height: height, received: time.Now()})
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber) pop() (block, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 || s.stopped {
+		return block{}, false
+	}
+
+	b := s.queue[0]
+	s.queue = s.queue[1:]
+	return b, true
+}
+
+func (s *subscriber) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.stopped {
+		s.stopped = true
+		close(s.signal)
+	}
+}
+
+func (s *subscriber) run(t *Timer) {
+	for range s.signal {
+		for {
+			b, ok := s.pop()
+			if !ok {
+				break
+			}
+
+			t.notify(b.height, b.received, s.name, s.f)
+		}
+	}
 }
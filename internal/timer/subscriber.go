@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 	"gitlab.com/distributed_lab/logan/v3"
 )
 
@@ -12,10 +14,17 @@ const (
 	BlockServiceName = "block-subscriber"
 	BlockQuery       = "tm.event = 'NewBlock'"
 	ChanelCap        = 100
+	// PollingPeriod defines the period of the status polling if subscription is not available
+	// or no blocks were received during this period
+	PollingPeriod = 5 * time.Second
+	// ResubscribePeriod defines the period of polling before the next subscription attempt
+	ResubscribePeriod = 30 * time.Second
 )
 
 // BlockSubscriber subscribes to the NewBlock events on the tendermint core.
-// New blocks indexes will be pushed to the timer and used in future for session timestamping
+// If subscription is not available it falls back to the status polling until the next subscription attempt.
+// New blocks indexes will be pushed to the timer and used in future for session timestamping.
+// All intermediate heights between received blocks are pushed to the timer in order.
 type BlockSubscriber struct {
 	timer  *Timer
 	client *http.HTTP
@@ -33,25 +42,118 @@ func NewBlockSubscriber(timer *Timer, tendermint *http.HTTP, log *logan.Entry) *
 
 func (b *BlockSubscriber) Run(ctx context.Context) {
 	go func() {
-		ticker := time.NewTicker(5 * time.Second)
-		defer ticker.Stop()
-
 		for {
-			select {
-			case <-ctx.Done():
-				b.log.Info("Context finished")
-				return
-			case <-ticker.C:
-				info, err := b.client.Status(ctx)
-				if err != nil {
-					b.log.WithError(err).Fatal("[Block] failed to receive status")
+			b.log.Infof("[Block] Subscribing to the new blocks. Query: %s", BlockQuery)
+
+			out, err := b.client.Subscribe(ctx, BlockServiceName, BlockQuery, ChanelCap)
+			if err != nil {
+				b.log.WithError(err).Error("[Block] Failed to subscribe to the new blocks. Falling back to polling")
+				if !b.poll(ctx, ResubscribePeriod) {
+					return
 				}
 
-				b.log.Infof("[Block] Received New Block %s height: %d", info.SyncInfo.LatestBlockHash, info.SyncInfo.LatestBlockHeight)
-				b.timer.newBlock(uint64(info.SyncInfo.LatestBlockHeight))
+				continue
+			}
+
+			if !b.listen(ctx, out) {
+				return
+			}
 
-				ticker.Reset(5 * time.Second)
+			b.log.Warn("[Block] Subscription channel closed. Falling back to polling")
+			if err := b.client.Unsubscribe(context.Background(), BlockServiceName, BlockQuery); err != nil {
+				b.log.WithError(err).Debug("[Block] Failed to unsubscribe from the new blocks")
+			}
+
+			if !b.poll(ctx, ResubscribePeriod) {
+				return
 			}
 		}
 	}()
 }
+
+// listen receives the new blocks from subscription. If no blocks were received during the polling period
+// the status will be requested to not miss the blocks. Returns false if context is finished
+// and true if subscription channel has been closed.
+func (b *BlockSubscriber) listen(ctx context.Context, out <-chan coretypes.ResultEvent) bool {
+	ticker := time.NewTicker(PollingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := b.client.Unsubscribe(context.Background(), BlockServiceName, BlockQuery); err != nil {
+				b.log.WithError(err).Error("[Block] Failed to unsubscribe from the new blocks")
+			}
+
+			b.log.Info("Context finished")
+			return false
+		case event, ok := <-out:
+			if !ok {
+				return true
+			}
+
+			data, ok := event.Data.(tmtypes.EventDataNewBlock)
+			if !ok || data.Block == nil {
+				continue
+			}
+
+			b.log.Infof("[Block] Received New Block %s height: %d", data.Block.Hash(), data.Block.Height)
+			b.newBlock(uint64(data.Block.Height))
+			ticker.Reset(PollingPeriod)
+		case <-ticker.C:
+			b.requestStatus(ctx)
+		}
+	}
+}
+
+// poll requests the status every polling period during the provided duration.
+// Returns false if context is finished.
+func (b *BlockSubscriber) poll(ctx context.Context, duration time.Duration) bool {
+	ticker := time.NewTicker(PollingPeriod)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(duration)
+	defer timeout.Stop()
+
+	b.requestStatus(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			b.log.Info("Context finished")
+			return false
+		case <-timeout.C:
+			return true
+		case <-ticker.C:
+			b.requestStatus(ctx)
+		}
+	}
+}
+
+func (b *BlockSubscriber) requestStatus(ctx context.Context) {
+	info, err := b.client.Status(ctx)
+	if err != nil {
+		b.log.WithError(err).Error("[Block] Failed to receive status")
+		return
+	}
+
+	b.log.Infof("[Block] Received status %s height: %d", info.SyncInfo.LatestBlockHash, info.SyncInfo.LatestBlockHeight)
+	b.newBlock(uint64(info.SyncInfo.LatestBlockHeight))
+}
+
+// newBlock pushes to the timer all heights after the last pushed one up to the provided height.
+// Heights that have already been pushed are ignored.
+func (b *BlockSubscriber) newBlock(height uint64) {
+	last := b.timer.CurrentBlock()
+	if height <= last {
+		return
+	}
+
+	if height-last > 1 {
+		b.log.Warnf("[Block] Filling the gap between blocks %d and %d", last, height)
+	}
+
+	for h := last + 1; h <= height; h++ {
+		b.timer.newBlock(h)
+	}
+}
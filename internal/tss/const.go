@@ -3,5 +3,6 @@ package tss
 const (
 	OutChannelSize = 1000
 	EndChannelSize = 1
-	WaitingCap     = 100
+	// WaitingCap defines the maximum amount of messages buffered per sender before the tss party start
+	WaitingCap = 100
 )
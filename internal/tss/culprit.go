@@ -0,0 +1,79 @@
+package tss
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+)
+
+// Culprit describes the party blamed for the tss protocol failure.
+type Culprit struct {
+	Account string               `json:"account"`
+	Type    rarimo.ViolationType `json:"type"`
+	Message string               `json:"message"`
+}
+
+// ErrorCulprits returns the culprits of the tss protocol error excluding self party.
+// Returns nil if error is not a tss error or does not blame any other party.
+func ErrorCulprits(err error, self string) []Culprit {
+	var tssErr *tss.Error
+	if !errors.As(err, &tssErr) || tssErr == nil {
+		return nil
+	}
+
+	var result []Culprit
+	for _, party := range tssErr.Culprits() {
+		if party == nil || party.Id == self {
+			continue
+		}
+
+		result = append(result, Culprit{
+			Account: party.Id,
+			Type:    rarimo.ViolationType_Other,
+			Message: fmt.Sprintf("Party violated tss %s protocol in round %d: %v", tssErr.Task(), tssErr.Round(), tssErr.Cause()),
+		})
+	}
+
+	return result
+}
+
+// waitingCulprits returns the parties that have not sent the messages required by the current tss party round.
+func waitingCulprits(party tss.Party, self string) []Culprit {
+	waiting := party.WaitingFor()
+	result := make([]Culprit, 0, len(waiting))
+	for _, p := range waiting {
+		if p.Id == self {
+			continue
+		}
+
+		result = append(result, Culprit{
+			Account: p.Id,
+			Type:    rarimo.ViolationType_Offline,
+			Message: "Party has not sent tss messages in time",
+		})
+	}
+
+	return result
+}
+
+// culpritSet collects the culprits found during the tss party execution.
+type culpritSet struct {
+	mu   sync.Mutex
+	list []Culprit
+}
+
+func (c *culpritSet) add(culprits ...Culprit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.list = append(c.list, culprits...)
+}
+
+// Culprits returns the parties blamed for the failed party start or for the round timeout.
+func (c *culpritSet) Culprits() []Culprit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Culprit{}, c.list...)
+}
@@ -0,0 +1,299 @@
+package tss
+
+import (
+	"context"
+	goerr "errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/crypto"
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/connectors"
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	ErrInvalidEchoRequest = goerr.New("invalid echo request")
+	// ErrForeignRequest is returned if request does not belong to the current party (e.g. late echo of the previous signing)
+	ErrForeignRequest = goerr.New("request does not belong to the current party")
+)
+
+// detailsExtractor returns the tss message details from the broadcast request data.
+type detailsExtractor func(data *types.RequestData) ([]byte, error)
+
+type echoKey struct {
+	origin string
+	round  string
+}
+
+type echoMessage struct {
+	request   *types.MsgSubmitRequest
+	receivers []*rarimo.Party
+}
+
+// echoBroadcast implements the echo round for the tss broadcast messages. Every party resends the signed broadcast
+// requests received from other parties to the rest of receivers and compares the hashes of requests received from
+// the same origin in the same round. Different requests signed by the origin prove that it has sent different broadcast
+// messages to different parties (equivocation): the party aborts and the origin is blamed.
+type echoBroadcast struct {
+	mu sync.Mutex
+
+	log      *logan.Entry
+	secret   *secret.TssSecret
+	con      *connectors.BroadcastConnector
+	core     *connectors.CoreConnector
+	auth     *core.RequestAuthorizer
+	culprits *culpritSet
+
+	id          uint64
+	sessionType types.SessionType
+	requestType types.RequestType
+	scheme      types.KeyScheme
+	details     detailsExtractor
+
+	// hashes contains the first received request hash for every origin and round
+	hashes map[echoKey]string
+	// expected contains the accounts that should echo the broadcast requests received by self party
+	expected map[echoKey][]string
+	echoed   map[echoKey]map[string]struct{}
+
+	equivocated atomic.Bool
+	sending     atomic.Bool
+	out         chan echoMessage
+}
+
+func newEchoBroadcast(
+	id uint64,
+	sessionType types.SessionType,
+	requestType types.RequestType,
+	scheme types.KeyScheme,
+	parties []*rarimo.Party,
+	details detailsExtractor,
+	culprits *culpritSet,
+	secret *secret.TssSecret,
+	con *connectors.BroadcastConnector,
+	coreCon *connectors.CoreConnector,
+	log *logan.Entry,
+) *echoBroadcast {
+	return &echoBroadcast{
+		log:         log,
+		secret:      secret,
+		con:         con,
+		core:        coreCon,
+		auth:        core.NewRequestAuthorizer(parties, log),
+		culprits:    culprits,
+		id:          id,
+		sessionType: sessionType,
+		requestType: requestType,
+		scheme:      scheme,
+		details:     details,
+		hashes:      make(map[echoKey]string),
+		expected:    make(map[echoKey][]string),
+		echoed:      make(map[echoKey]map[string]struct{}),
+		out:         make(chan echoMessage, OutChannelSize),
+	}
+}
+
+// Equivocated returns true if any party has sent different broadcast messages in the same round.
+func (e *echoBroadcast) Equivocated() bool {
+	return e.equivocated.Load()
+}
+
+// done returns true if all echoes have been sent and all expected echoes have been received.
+func (e *echoBroadcast) done() bool {
+	if len(e.out) != 0 || e.sending.Load() {
+		return false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for key, accounts := range e.expected {
+		for _, account := range accounts {
+			if _, ok := e.echoed[key][account]; !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// received records the broadcast request received from the origin party and schedules its echo to the rest of receivers.
+func (e *echoBroadcast) received(origin *rarimo.Party, request *types.MsgSubmitRequest, receivers []*rarimo.Party) error {
+	key, hash, err := e.keyOf(origin, request)
+	if err != nil {
+		return err
+	}
+
+	self := e.secret.AccountAddress()
+	echoTo := make([]*rarimo.Party, 0, len(receivers))
+	accounts := make([]string, 0, len(receivers))
+	for _, receiver := range receivers {
+		if receiver.Account == self || receiver.Account == origin.Account {
+			continue
+		}
+
+		echoTo = append(echoTo, receiver)
+		accounts = append(accounts, receiver.Account)
+	}
+
+	e.mu.Lock()
+	_, echoed := e.expected[key]
+	e.expected[key] = accounts
+	e.check(key, hash)
+	e.mu.Unlock()
+
+	// The request of the same origin round has already been echoed: duplicates are detected by hashes comparison
+	if echoed {
+		return nil
+	}
+
+	details, err := anypb.New(&types.EchoRequest{Request: request})
+	if err != nil {
+		return errors.Wrap(err, "failed to parse echo details")
+	}
+
+	msg := echoMessage{
+		request: &types.MsgSubmitRequest{
+			Data: &types.RequestData{
+				Type:        types.RequestType_Echo,
+				Id:          e.id,
+				IsBroadcast: true,
+				Details:     details,
+				Scheme:      e.scheme,
+			},
+		},
+		receivers: echoTo,
+	}
+
+	// Receiving should never block the session, so the echo is dropped if the outgoing queue is full
+	select {
+	case e.out <- msg:
+	default:
+		e.log.Warnf("Echo queue is full, echo of %s request in round %s dropped", origin.Account, key.round)
+	}
+
+	return nil
+}
+
+// Echo accepts the broadcast request echoed by the sender party. Returns an error if echoed request has not been signed
+// by the origin or does not belong to the current party.
+func (e *echoBroadcast) Echo(sender *rarimo.Party, request *types.MsgSubmitRequest) error {
+	echo := new(types.EchoRequest)
+	if err := request.Data.Details.UnmarshalTo(echo); err != nil {
+		return errors.Wrap(err, "error unmarshalling echo request")
+	}
+
+	if echo.Request == nil || echo.Request.Data == nil {
+		return ErrInvalidEchoRequest
+	}
+
+	origin, err := e.auth.Auth(echo.Request)
+	if err != nil {
+		return errors.Wrap(err, "failed to authorize echoed request")
+	}
+
+	// Self messages are known without echoes, origin echoes are meaningless
+	if origin.Account == e.secret.AccountAddress() || origin.Account == sender.Account {
+		return nil
+	}
+
+	key, hash, err := e.keyOf(origin, echo.Request)
+	if goerr.Is(err, ErrForeignRequest) {
+		e.log.Debugf("Echo from %s does not belong to the current party", sender.Account)
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.echoed[key]; !ok {
+		e.echoed[key] = make(map[string]struct{})
+	}
+	e.echoed[key][sender.Account] = struct{}{}
+
+	e.check(key, hash)
+	return nil
+}
+
+// check compares the request hash with the previously received one. Should be called under the lock.
+func (e *echoBroadcast) check(key echoKey, hash string) {
+	prev, ok := e.hashes[key]
+	if !ok {
+		e.hashes[key] = hash
+		return
+	}
+
+	if prev != hash {
+		e.log.Errorf("Party %s has sent different broadcast messages in round %s", key.origin, key.round)
+		e.equivocated.Store(true)
+		e.culprits.add(Culprit{
+			Account: key.origin,
+			Type:    rarimo.ViolationType_Other,
+			Message: fmt.Sprintf("Party has sent different tss broadcast messages in round %s", key.round),
+		})
+	}
+}
+
+// keyOf validates the broadcast request and returns its origin round and hash.
+func (e *echoBroadcast) keyOf(origin *rarimo.Party, request *types.MsgSubmitRequest) (echoKey, string, error) {
+	data := request.Data
+	if !data.IsBroadcast {
+		return echoKey{}, "", ErrInvalidEchoRequest
+	}
+
+	if data.Id != e.id || data.SessionType != e.sessionType || data.Type != e.requestType || data.Scheme != e.scheme {
+		return echoKey{}, "", ErrForeignRequest
+	}
+
+	details, err := e.details(data)
+	if err != nil {
+		return echoKey{}, "", err
+	}
+
+	wire := new(anypb.Any)
+	if err := proto.Unmarshal(details, wire); err != nil {
+		return echoKey{}, "", errors.Wrap(err, "failed to unmarshal tss message")
+	}
+
+	// The same hash is signed by the origin party (see core.RequestAuthorizer)
+	raw, err := anypb.New(data)
+	if err != nil {
+		return echoKey{}, "", err
+	}
+
+	return echoKey{origin: origin.Account, round: wire.TypeUrl}, hexutil.Encode(eth.Keccak256(raw.Value)), nil
+}
+
+func (e *echoBroadcast) run(ctx context.Context, wg *sync.WaitGroup) {
+	defer func() {
+		e.log.Debug("Sending echo messages finished")
+		wg.Done()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-e.out:
+			e.sending.Store(true)
+			if failed := e.con.SubmitToWithReport(ctx, e.core, msg.request, msg.receivers...); len(failed) != 0 {
+				e.con.SubmitToWithReport(ctx, e.core, msg.request, failed...)
+			}
+			e.sending.Store(false)
+		}
+	}
+}
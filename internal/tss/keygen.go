@@ -5,23 +5,29 @@ import (
 	"crypto/elliptic"
 	"math/big"
 	"sync"
+	"sync/atomic"
 
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	s256k1 "github.com/btcsuite/btcd/btcec/v2"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/rarimo/rarimo-core/x/rarimocore/crypto"
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/config"
 	"github.com/rarimo/tss-svc/internal/connectors"
 	"github.com/rarimo/tss-svc/internal/core"
 	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/internal/timer"
 	"github.com/rarimo/tss-svc/pkg/types"
 	"gitlab.com/distributed_lab/logan/v3"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 type KeygenParty struct {
+	culpritSet
 	wg *sync.WaitGroup
 
 	log *logan.Entry
@@ -33,76 +39,136 @@ type KeygenParty struct {
 	party tss.Party
 	con   *connectors.BroadcastConnector
 	core  *connectors.CoreConnector
+	echo  *echoBroadcast
 
-	id     uint64
-	result *keygen.LocalPartySaveData
+	id          uint64
+	scheme      types.KeyScheme
+	result      *keygen.LocalPartySaveData
+	eddsaResult *eddsakeygen.LocalPartySaveData
 
-	waiting chan waitingMessage
+	waiting *waitingQueue
+
+	outbox *outbox
+	done   atomic.Bool
 }
 
-func NewKeygenParty(id uint64, sessionType types.SessionType, parties []*rarimo.Party, secret *secret.TssSecret, coreCon *connectors.CoreConnector, log *logan.Entry) *KeygenParty {
-	return &KeygenParty{
+func NewKeygenParty(id uint64, sessionType types.SessionType, scheme types.KeyScheme, parties []*rarimo.Party, secret *secret.TssSecret, transport connectors.Transport, timer *timer.Timer, broadcast *config.BroadcastInfo, coreCon *connectors.CoreConnector, log *logan.Entry) *KeygenParty {
+	k := &KeygenParty{
 		id:       id,
+		scheme:   scheme,
 		wg:       &sync.WaitGroup{},
 		log:      log,
 		partyIds: core.PartyIds(parties),
 		parties:  partiesByAccountMapping(parties),
 		secret:   secret,
-		con:      connectors.NewBroadcastConnector(sessionType, parties, secret, log),
+		con:      connectors.NewBroadcastConnector(sessionType, parties, secret, transport, timer, broadcast, log),
 		core:     coreCon,
-		waiting:  make(chan waitingMessage, WaitingCap),
 	}
+
+	k.echo = newEchoBroadcast(id, sessionType, types.RequestType_Keygen, scheme, parties, requestDetails, &k.culpritSet, secret, k.con, coreCon, log)
+	k.outbox = newOutbox()
+	k.waiting = newWaitingQueue(&k.culpritSet)
+	return k
 }
 
+// Result returns the generated ECDSA key share. Returns nil for EdDSA keygen party or if any party has equivocated.
 func (k *KeygenParty) Result() *keygen.LocalPartySaveData {
+	if k.echo.Equivocated() {
+		return nil
+	}
+
 	return k.result
 }
 
-func (k *KeygenParty) Receive(sender *rarimo.Party, isBroadcast bool, details []byte) error {
-	if k.party != nil {
-		k.receiveWaiting()
-		return k.receive(sender, isBroadcast, details)
+// EdDSAResult returns the generated EdDSA key share. Returns nil for ECDSA keygen party or if any party has equivocated.
+func (k *KeygenParty) EdDSAResult() *eddsakeygen.LocalPartySaveData {
+	if k.echo.Equivocated() {
+		return nil
 	}
 
-	k.pushToWaiting(sender, isBroadcast, details)
+	return k.eddsaResult
+}
 
-	return nil
+// Done returns true if the key has been generated and all outgoing messages and echoes have been sent and received.
+// Party is also done if any party has equivocated: key generation is aborted in such case.
+func (k *KeygenParty) Done() bool {
+	return k.echo.Equivocated() || (k.done.Load() && k.outbox.idle() && k.echo.done())
 }
 
-func (k *KeygenParty) receive(sender *rarimo.Party, isBroadcast bool, details []byte) error {
-	k.log.Debugf("Received keygen request from %s", sender.Account)
-	_, data, _ := bech32.DecodeAndConvert(sender.Account)
-	_, err := k.party.UpdateFromBytes(details, k.partyIds.FindByKey(new(big.Int).SetBytes(data)), isBroadcast)
+func (k *KeygenParty) Receive(sender *rarimo.Party, request *types.MsgSubmitRequest, details []byte) error {
+	queued, err := k.waiting.push(waitingMessage{sender: sender, request: request, details: details})
+	if err != nil || queued {
+		return err
+	}
+
+	return k.receive(sender, request, details)
+}
+
+// Echo accepts the broadcast request echoed by the sender party.
+func (k *KeygenParty) Echo(sender *rarimo.Party, request *types.MsgSubmitRequest) error {
+	return k.echo.Echo(sender, request)
+}
+
+func (k *KeygenParty) receive(sender *rarimo.Party, request *types.MsgSubmitRequest, details []byte) error {
+	k.log.Debugf("Received %s keygen request from %s", k.scheme.String(), sender.Account)
+	isBroadcast := request.Data.IsBroadcast
+	details, err := decrypt(k.secret, sender, isBroadcast, details)
 	if err != nil {
 		return err
 	}
+
+	if isBroadcast && sender.Account != k.secret.AccountAddress() {
+		if err := k.echo.received(sender, request, partiesList(k.parties)); err != nil {
+			return err
+		}
+	}
+
+	_, data, _ := bech32.DecodeAndConvert(sender.Account)
+	// Party returns *tss.Error, so it is not assigned to the error variable to keep nil result nil
+	if err := k.outbox.update(k.party, details, k.partyIds.FindByKey(new(big.Int).SetBytes(data)), isBroadcast); err != nil {
+		return err
+	}
 	logPartyStatus(k.log, k.party, k.secret.AccountAddress())
-	k.log.Debugf("Finished processing keygen request from %s", sender.Account)
+	k.log.Debugf("Finished processing %s keygen request from %s", k.scheme.String(), sender.Account)
 
 	return nil
 }
 
 func (k *KeygenParty) Run(ctx context.Context) {
-	k.log.Infof("Running TSS key generation on set: %v", k.parties)
+	k.log.Infof("Running TSS %s key generation on set: %v", k.scheme.String(), k.parties)
 	self := k.partyIds.FindByKey(core.GetTssPartyKey(k.secret.AccountAddress()))
-	out := make(chan tss.Message, OutChannelSize)
-	end := make(chan *keygen.LocalPartySaveData, EndChannelSize)
 	peerCtx := tss.NewPeerContext(k.partyIds)
-	params := tss.NewParameters(tss.S256(), peerCtx, self, k.partyIds.Len(), crypto.GetThreshold(k.partyIds.Len()))
 
-	tss.S256()
-	k.party = k.secret.GetKeygenParty(params, out, end)
+	k.wg.Add(3)
+	go k.echo.run(ctx, k.wg)
+
+	var closeEnd func()
+	switch k.scheme {
+	case types.KeyScheme_EdDSA:
+		end := make(chan *eddsakeygen.LocalPartySaveData, EndChannelSize)
+		params := tss.NewParameters(tss.Edwards(), peerCtx, self, k.partyIds.Len(), crypto.GetThreshold(k.partyIds.Len()))
+		k.party = k.secret.GetEdDSAKeygenParty(params, k.outbox.out, end)
+		closeEnd = func() { close(end) }
+		go k.runEdDSA(ctx, end)
+	default:
+		end := make(chan *keygen.LocalPartySaveData, EndChannelSize)
+		params := tss.NewParameters(tss.S256(), peerCtx, self, k.partyIds.Len(), crypto.GetThreshold(k.partyIds.Len()))
+		k.party = k.secret.GetKeygenParty(params, k.outbox.out, end)
+		closeEnd = func() { close(end) }
+		go k.run(ctx, end)
+	}
+
 	go func() {
-		err := k.party.Start()
+		err := k.outbox.process(k.party.Start)
 		if err != nil {
 			k.log.WithError(err).Error("Error running tss party")
-			close(end)
+			k.add(ErrorCulprits(err, k.secret.AccountAddress())...)
+			closeEnd()
 		}
 	}()
 
-	k.wg.Add(2)
-	go k.run(ctx, end)
-	go k.listenOutput(ctx, out)
+	go k.listenOutput(ctx)
+	k.receiveWaiting()
 }
 
 func (k *KeygenParty) WaitFor() {
@@ -111,106 +177,137 @@ func (k *KeygenParty) WaitFor() {
 	k.log.Debug("Keygen party group finished")
 }
 
+// receiveWaiting processes the messages received before the party initialization.
 func (k *KeygenParty) receiveWaiting() {
-	if len(k.waiting) == 0 {
+	messages := k.waiting.open()
+	if len(messages) == 0 {
 		return
 	}
 
 	k.log.Debug("Processing waiting messages")
 
-	for {
+	for _, msg := range messages {
+		if err := k.receive(msg.sender, msg.request, msg.details); err != nil {
+			k.log.WithError(err).Error("failed to receive waiting message")
+		}
+	}
+}
+
+func (k *KeygenParty) run(ctx context.Context, end <-chan *keygen.LocalPartySaveData) {
+	defer func() {
+		k.log.Debug("Listening to keygen party result finished")
+		k.wg.Done()
+	}()
+
+	var (
+		result *keygen.LocalPartySaveData
+		ok     bool
+	)
+
+	select {
+	case result, ok = <-end:
+	case <-ctx.Done():
 		select {
-		case msg := <-k.waiting:
-			if err := k.receive(msg.sender, msg.isBroadcast, msg.details); err != nil {
-				k.log.WithError(err).Error("failed to receive waiting message")
-			}
+		case result, ok = <-end:
 		default:
+			k.log.Error("Keygen process has not been finished yet or has some errors")
+			k.add(waitingCulprits(k.party, k.secret.AccountAddress())...)
 			return
 		}
 	}
-}
 
-func (k *KeygenParty) pushToWaiting(sender *rarimo.Party, isBroadcast bool, details []byte) {
-	k.log.Debug("Message will be pushed to the waiting queue")
-	k.waiting <- waitingMessage{
-		sender:      sender,
-		isBroadcast: isBroadcast,
-		details:     details,
+	if !ok {
+		k.log.Error("TSS party chanel closed")
+		return
 	}
+
+	k.log.Infof("New generated public key: %s", hexutil.Encode(elliptic.Marshal(s256k1.S256(), result.ECDSAPub.X(), result.ECDSAPub.Y())))
+	k.result = result
+	k.done.Store(true)
 }
 
-func (k *KeygenParty) run(ctx context.Context, end <-chan *keygen.LocalPartySaveData) {
+func (k *KeygenParty) runEdDSA(ctx context.Context, end <-chan *eddsakeygen.LocalPartySaveData) {
 	defer func() {
-		k.log.Debug("Listening to keygen party result finished")
+		k.log.Debug("Listening to EdDSA keygen party result finished")
 		k.wg.Done()
 	}()
 
-	<-ctx.Done()
+	var (
+		result *eddsakeygen.LocalPartySaveData
+		ok     bool
+	)
 
 	select {
-	case result, ok := <-end:
-		if !ok {
-			k.log.Error("TSS party chanel closed")
+	case result, ok = <-end:
+	case <-ctx.Done():
+		select {
+		case result, ok = <-end:
+		default:
+			k.log.Error("EdDSA keygen process has not been finished yet or has some errors")
+			k.add(waitingCulprits(k.party, k.secret.AccountAddress())...)
 			return
 		}
+	}
 
-		k.log.Infof("New generated public key: %s", hexutil.Encode(elliptic.Marshal(s256k1.S256(), result.ECDSAPub.X(), result.ECDSAPub.Y())))
-		k.result = result
-	default:
-		k.log.Error("Keygen process has not been finished yet or has some errors")
+	if !ok {
+		k.log.Error("TSS party chanel closed")
+		return
 	}
+
+	k.log.Infof("New generated EdDSA public key: %s", hexutil.Encode(edwards.NewPublicKey(result.EDDSAPub.X(), result.EDDSAPub.Y()).SerializeCompressed()))
+	k.eddsaResult = result
+	k.done.Store(true)
 }
 
-func (k *KeygenParty) listenOutput(ctx context.Context, out <-chan tss.Message) {
+func (k *KeygenParty) listenOutput(ctx context.Context) {
 	defer func() {
 		k.log.Debug("Listening to keygen party output finished")
 		k.wg.Done()
 	}()
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case msg := <-out:
-			details, err := anypb.New(msg.WireMsg().Message)
-			if err != nil {
-				k.log.WithError(err).Error("Failed to parse details")
-				continue
-			}
-
-			request := &types.MsgSubmitRequest{
-				Data: &types.RequestData{
-					Type:        types.RequestType_Keygen,
-					Id:          k.id,
-					IsBroadcast: msg.IsBroadcast(),
-					Details:     details,
-				},
-			}
+	k.outbox.run(ctx, k.send)
+}
 
-			to := msg.GetTo()
-			if msg.IsBroadcast() {
-				to = k.partyIds
-			}
+func (k *KeygenParty) send(ctx context.Context, msg tss.Message) {
+	details, err := anypb.New(msg.WireMsg().Message)
+	if err != nil {
+		k.log.WithError(err).Error("Failed to parse details")
+		return
+	}
 
-			receivers := make([]*rarimo.Party, 0, len(to))
+	build := func(details *anypb.Any) (*types.MsgSubmitRequest, error) {
+		return &types.MsgSubmitRequest{
+			Data: &types.RequestData{
+				Type:        types.RequestType_Keygen,
+				Id:          k.id,
+				IsBroadcast: msg.IsBroadcast(),
+				Details:     details,
+				Scheme:      k.scheme,
+			},
+		}, nil
+	}
 
-			for _, receiver := range to {
-				party, _ := k.parties[receiver.Id]
+	to := msg.GetTo()
+	if msg.IsBroadcast() {
+		to = k.partyIds
+	}
 
-				if party.Account == k.secret.AccountAddress() {
-					k.log.Debugf("Sending to self (%s)", party.Account)
-					if err := k.Receive(party, msg.IsBroadcast(), details.Value); err != nil {
-						k.log.WithError(err).Error("error submitting request to self")
-					}
-					continue
-				}
+	receivers := make([]*rarimo.Party, 0, len(to))
 
-				receivers = append(receivers, party)
-			}
+	for _, receiver := range to {
+		party, _ := k.parties[receiver.Id]
 
-			if failed := k.con.SubmitToWithReport(ctx, k.core, request, receivers...); len(failed) != 0 {
-				k.con.SubmitToWithReport(ctx, k.core, request, failed...)
+		if party.Account == k.secret.AccountAddress() {
+			k.log.Debugf("Sending to self (%s)", party.Account)
+			request, _ := build(details)
+			if err := k.Receive(party, request, details.Value); err != nil {
+				k.log.WithError(err).Error("error submitting request to self")
 			}
+			continue
 		}
+
+		receivers = append(receivers, party)
 	}
+
+	submit(ctx, k.con, k.core, k.secret, msg.IsBroadcast(), details, build, k.log, receivers...)
 }
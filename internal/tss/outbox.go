@@ -0,0 +1,106 @@
+package tss
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// outbox collects the messages produced by the tss party and counts the outstanding ones: messages that have been
+// produced but not sent yet and party calls that can still produce messages. The tss party produces messages only
+// inside Start and Update calls (see process), so the party is idle only if the counter is zero.
+// Channel length is never used to check idleness, because the message taken from the channel is not sent yet.
+type outbox struct {
+	mu       sync.Mutex
+	messages []tss.Message
+	notify   chan struct{}
+
+	// out is the channel the tss party writes its messages to
+	out         chan tss.Message
+	outstanding atomic.Int64
+}
+
+func newOutbox() *outbox {
+	return &outbox{
+		notify: make(chan struct{}, 1),
+		out:    make(chan tss.Message, OutChannelSize),
+	}
+}
+
+// process runs the tss party call that can produce messages. The call is counted as outstanding until all messages
+// it has produced are counted themselves. Messages are collected after the call, so the party channel should fit
+// all messages of one protocol round (see OutChannelSize).
+func (o *outbox) process(call func() *tss.Error) *tss.Error {
+	o.outstanding.Add(1)
+	defer o.outstanding.Add(-1)
+
+	err := call()
+	o.collect()
+	return err
+}
+
+// update delivers the message to the tss party (see process).
+func (o *outbox) update(party tss.Party, wireBytes []byte, from *tss.PartyID, isBroadcast bool) *tss.Error {
+	return o.process(func() *tss.Error {
+		_, err := party.UpdateFromBytes(wireBytes, from, isBroadcast)
+		return err
+	})
+}
+
+// collect moves the produced messages from the party channel to the queue. Every message is counted before
+// the outstanding call that has produced it is finished.
+func (o *outbox) collect() {
+	for {
+		select {
+		case msg := <-o.out:
+			o.outstanding.Add(1)
+			o.mu.Lock()
+			o.messages = append(o.messages, msg)
+			o.mu.Unlock()
+
+			select {
+			case o.notify <- struct{}{}:
+			default:
+			}
+		default:
+			return
+		}
+	}
+}
+
+// idle returns true if there are no outstanding messages and calls.
+func (o *outbox) idle() bool {
+	return o.outstanding.Load() == 0
+}
+
+// run sends the queued messages one by one until the context is canceled.
+// The message stays outstanding until send returns.
+func (o *outbox) run(ctx context.Context, send func(ctx context.Context, msg tss.Message)) {
+	for ctx.Err() == nil {
+		if msg, ok := o.pop(); ok {
+			send(ctx, msg)
+			o.outstanding.Add(-1)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-o.notify:
+		}
+	}
+}
+
+func (o *outbox) pop() (tss.Message, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.messages) == 0 {
+		return nil, false
+	}
+
+	msg := o.messages[0]
+	o.messages = o.messages[1:]
+	return msg, true
+}
@@ -0,0 +1,277 @@
+package tss
+
+import (
+	"context"
+	"crypto/elliptic"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/crypto"
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/config"
+	"github.com/rarimo/tss-svc/internal/connectors"
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/internal/timer"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
+)
+
+const (
+	testPartiesCount = 3
+	testTimeout      = 5 * time.Minute
+)
+
+// testParty is the common part of the keygen and signing parties
+type testParty interface {
+	Run(ctx context.Context)
+	WaitFor()
+	Done() bool
+	Receive(sender *rarimo.Party, request *types.MsgSubmitRequest, details []byte) error
+	Echo(sender *rarimo.Party, request *types.MsgSubmitRequest) error
+}
+
+// testNode delivers the requests received over the in-memory transport to its current party
+// in the same way as the session controllers do.
+type testNode struct {
+	mu      sync.RWMutex
+	secret  *secret.TssSecret
+	core    *connectors.CoreConnector
+	auth    *core.RequestAuthorizer
+	log     *logan.Entry
+	current testParty
+}
+
+var _ connectors.Receiver = &testNode{}
+
+func (n *testNode) Receive(_ context.Context, request *types.MsgSubmitRequest) error {
+	sender, err := n.auth.Auth(request)
+	if err != nil {
+		return err
+	}
+
+	n.mu.RLock()
+	party := n.current
+	n.mu.RUnlock()
+
+	switch request.Data.Type {
+	case types.RequestType_Keygen:
+		return party.Receive(sender, request, request.Data.Details.Value)
+	case types.RequestType_Sign:
+		sign := new(types.SignRequest)
+		if err := request.Data.Details.UnmarshalTo(sign); err != nil {
+			return err
+		}
+		return party.Receive(sender, request, sign.Details.Value)
+	case types.RequestType_Echo:
+		return party.Echo(sender, request)
+	}
+
+	return ErrInvalidEchoRequest
+}
+
+// testSet contains the nodes that run tss parties in one process over the shared in-memory transport
+type testSet struct {
+	parties   []*rarimo.Party
+	nodes     []*testNode
+	transport *connectors.InMemoryTransport
+	timer     *timer.Timer
+	broadcast *config.BroadcastInfo
+}
+
+func newTestSet(t *testing.T, withPreParams bool) *testSet {
+	set := &testSet{
+		transport: connectors.NewInMemoryTransport(),
+		timer:     new(timer.Timer),
+		broadcast: &config.BroadcastInfo{PartyTimeout: 10 * time.Second, MaxConcurrent: testPartiesCount},
+	}
+
+	for i := 0; i < testPartiesCount; i++ {
+		prv, err := eth.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var params *keygen.LocalPreParams
+		if withPreParams {
+			if params, err = keygen.GeneratePreParams(testTimeout); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		sc := secret.NewTssSecret(prv, secp256k1.GenPrivKey(), nil, params, false)
+		log := logan.New().Level(logan.ErrorLevel).WithField("party", i)
+
+		set.nodes = append(set.nodes, &testNode{
+			secret: sc,
+			core:   connectors.NewCoreConnector(nil, sc, log, &config.ChainParams{DisableReports: true}),
+			log:    log,
+		})
+
+		set.parties = append(set.parties, &rarimo.Party{
+			PubKey:  sc.TssPubKey(),
+			Address: sc.AccountAddress(),
+			Account: sc.AccountAddress(),
+			Status:  rarimo.PartyStatus_Active,
+		})
+	}
+
+	for i, node := range set.nodes {
+		node.auth = core.NewRequestAuthorizer(set.parties, node.log)
+		set.transport.Register(set.parties[i].Address, node)
+	}
+
+	return set
+}
+
+// run launches the parties (one per node) and waits until all of them are done.
+func (s *testSet) run(t *testing.T, parties []testParty) {
+	for i, node := range s.nodes {
+		node.mu.Lock()
+		node.current = parties[i]
+		node.mu.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	for _, party := range parties {
+		party.Run(ctx)
+	}
+
+	for !s.done(parties) {
+		select {
+		case <-ctx.Done():
+			t.Fatal("parties have not been finished in time")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	for _, party := range parties {
+		party.WaitFor()
+	}
+}
+
+func (s *testSet) done(parties []testParty) bool {
+	for _, party := range parties {
+		if !party.Done() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *testSet) keygen(t *testing.T, scheme types.KeyScheme) []*KeygenParty {
+	keygenParties := make([]*KeygenParty, 0, len(s.nodes))
+	parties := make([]testParty, 0, len(s.nodes))
+	for _, node := range s.nodes {
+		party := NewKeygenParty(1, types.SessionType_KeygenSession, scheme, s.parties, node.secret, s.transport, s.timer, s.broadcast, node.core, node.log)
+		keygenParties = append(keygenParties, party)
+		parties = append(parties, party)
+	}
+
+	s.run(t, parties)
+	return keygenParties
+}
+
+func (s *testSet) sign(t *testing.T, scheme types.KeyScheme, data string) []*SignParty {
+	signParties := make([]*SignParty, 0, len(s.nodes))
+	parties := make([]testParty, 0, len(s.nodes))
+	for _, node := range s.nodes {
+		party := NewSignParty(data, 2, types.SessionType_DefaultSession, scheme, s.parties, node.secret, s.transport, s.timer, s.broadcast, node.core, node.log)
+		signParties = append(signParties, party)
+		parties = append(parties, party)
+	}
+
+	s.run(t, parties)
+	return signParties
+}
+
+func TestEdDSAKeygenAndSign(t *testing.T) {
+	set := newTestSet(t, false)
+
+	var pubKey string
+	for i, party := range set.keygen(t, types.KeyScheme_EdDSA) {
+		result := party.EdDSAResult()
+		if result == nil {
+			t.Fatalf("party %d has not generated the key", i)
+		}
+
+		set.nodes[i].secret = set.nodes[i].secret.NewWithEdDSAData(result)
+		key := set.nodes[i].secret.GlobalEdDSAPubKey()
+		if pubKey != "" && key != pubKey {
+			t.Fatalf("party %d has generated different key: %s != %s", i, key, pubKey)
+		}
+		pubKey = key
+	}
+
+	// Data with leading zero bytes should be signed too
+	hash := eth.Keccak256([]byte("eddsa"))
+	hash[0], hash[1] = 0, 0
+	data := hexutil.Encode(hash)
+
+	for i, party := range set.sign(t, types.KeyScheme_EdDSA, data) {
+		result := party.Result()
+		if result == nil {
+			t.Fatalf("party %d has not produced the signature", i)
+		}
+
+		if err := VerifyEdDSA(data, hexutil.Encode(result.Signature), pubKey); err != nil {
+			t.Fatalf("party %d has produced invalid signature: %v", i, err)
+		}
+	}
+}
+
+func TestECDSAKeygenAndSign(t *testing.T) {
+	if testing.Short() {
+		t.Skip("pre-params generation is too slow for the short mode")
+	}
+
+	set := newTestSet(t, true)
+
+	var (
+		pubKey string
+		shares *keygen.LocalPartySaveData
+	)
+	for i, party := range set.keygen(t, types.KeyScheme_ECDSA) {
+		result := party.Result()
+		if result == nil {
+			t.Fatalf("party %d has not generated the key", i)
+		}
+
+		set.nodes[i].secret = set.nodes[i].secret.NewWithData(result)
+		key := set.nodes[i].secret.GlobalPubKey()
+		if pubKey != "" && key != pubKey {
+			t.Fatalf("party %d has generated different key: %s != %s", i, key, pubKey)
+		}
+		pubKey = key
+		shares = result
+	}
+
+	// Key share becomes the party tss key (see secret.TssSecret.NewWithData),
+	// so the requests are signed and encrypted with the public shares after keygen
+	partyIds := core.PartyIds(set.parties)
+	for _, party := range set.parties {
+		id := partyIds.FindByKey(core.GetTssPartyKey(party.Account))
+		marshalled := elliptic.Marshal(eth.S256(), shares.BigXj[id.Index].X(), shares.BigXj[id.Index].Y())
+		party.PubKey = hexutil.Encode(marshalled[1:])
+	}
+
+	data := hexutil.Encode(eth.Keccak256([]byte("ecdsa")))
+	for i, party := range set.sign(t, types.KeyScheme_ECDSA, data) {
+		result := party.Result()
+		if result == nil {
+			t.Fatalf("party %d has not produced the signature", i)
+		}
+
+		if err := VerifyECDSA(data, hexutil.Encode(append(result.Signature, result.SignatureRecovery...)), pubKey); err != nil {
+			t.Fatalf("party %d has produced invalid signature: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,510 @@
+package tss
+
+import (
+	"context"
+	"crypto/elliptic"
+	goerr "errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	eddsaresharing "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	s256k1 "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rarimo/rarimo-core/x/rarimocore/crypto"
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/config"
+	"github.com/rarimo/tss-svc/internal/connectors"
+	"github.com/rarimo/tss-svc/internal/core"
+	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/internal/timer"
+	"github.com/rarimo/tss-svc/pkg/types"
+	"gitlab.com/distributed_lab/logan/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	ErrUnknownReshareMessage      = goerr.New("unknown resharing message")
+	ErrSenderIsNotCommitteeMember = goerr.New("sender is not a member of the resharing committee")
+)
+
+// ReshareParty distributes the current ECDSA or EdDSA key share of the old committee to the new committee
+// without changing the global public key. Party that belongs to both committees runs two tss parties:
+// the old committee party that shares its current key share and the new committee party that receives the new one.
+type ReshareParty struct {
+	culpritSet
+	wg *sync.WaitGroup
+
+	log *logan.Entry
+
+	oldIds     tss.SortedPartyIDs
+	newIds     tss.SortedPartyIDs
+	oldT       int
+	oldParties []*rarimo.Party
+	newParties []*rarimo.Party
+	parties    map[string]*rarimo.Party
+	generation uint64
+	secret     *secret.TssSecret
+
+	oldParty tss.Party
+	newParty tss.Party
+	con      *connectors.BroadcastConnector
+	core     *connectors.CoreConnector
+	echo     *echoBroadcast
+
+	id          uint64
+	scheme      types.KeyScheme
+	result      *keygen.LocalPartySaveData
+	eddsaResult *eddsakeygen.LocalPartySaveData
+
+	waiting *waitingQueue
+
+	outbox  *outbox
+	oldDone atomic.Bool
+	done    atomic.Bool
+}
+
+// NewReshareParty creates the resharing party. Old parties should hold the key share of the provided generation
+// with the threshold oldT. New parties will receive the key share of the next generation.
+func NewReshareParty(id uint64, sessionType types.SessionType, scheme types.KeyScheme, oldParties, newParties []*rarimo.Party, oldT int, generation uint64, secret *secret.TssSecret, transport connectors.Transport, timer *timer.Timer, broadcast *config.BroadcastInfo, coreCon *connectors.CoreConnector, log *logan.Entry) *ReshareParty {
+	all := append(append([]*rarimo.Party{}, oldParties...), newParties...)
+	r := &ReshareParty{
+		id:         id,
+		scheme:     scheme,
+		wg:         &sync.WaitGroup{},
+		log:        log,
+		oldIds:     core.ShareIds(oldParties, generation),
+		newIds:     core.ShareIds(newParties, generation+1),
+		oldT:       oldT,
+		oldParties: oldParties,
+		newParties: newParties,
+		parties:    partiesByAccountMapping(all),
+		generation: generation,
+		secret:     secret,
+		con:        connectors.NewBroadcastConnector(sessionType, newParties, secret, transport, timer, broadcast, log),
+		core:       coreCon,
+	}
+
+	r.echo = newEchoBroadcast(id, sessionType, types.RequestType_Keygen, scheme, all, requestDetails, &r.culpritSet, secret, r.con, coreCon, log)
+	r.outbox = newOutbox()
+	r.waiting = newWaitingQueue(&r.culpritSet)
+	return r
+}
+
+// Result returns the new ECDSA key share. Is nil for EdDSA resharing party, if resharing has not been finished
+// or if any party has equivocated.
+func (r *ReshareParty) Result() *keygen.LocalPartySaveData {
+	if r.echo.Equivocated() {
+		return nil
+	}
+
+	return r.result
+}
+
+// EdDSAResult returns the new EdDSA key share. Is nil for ECDSA resharing party, if resharing has not been finished
+// or if any party has equivocated.
+func (r *ReshareParty) EdDSAResult() *eddsakeygen.LocalPartySaveData {
+	if r.echo.Equivocated() {
+		return nil
+	}
+
+	return r.eddsaResult
+}
+
+// Generation returns the generation of the new key share.
+func (r *ReshareParty) Generation() uint64 {
+	return r.generation + 1
+}
+
+// Done returns true if the new key share has been received, the old key share has been distributed
+// (if self party belongs to the old committee) and all outgoing messages and echoes have been sent and received.
+// Party is also done if any party has equivocated: resharing is aborted in such case.
+func (r *ReshareParty) Done() bool {
+	return r.echo.Equivocated() ||
+		(r.done.Load() && (r.oldParty == nil || r.oldDone.Load()) && r.outbox.idle() && r.echo.done())
+}
+
+func (r *ReshareParty) Receive(sender *rarimo.Party, request *types.MsgSubmitRequest, details []byte) error {
+	queued, err := r.waiting.push(waitingMessage{sender: sender, request: request, details: details})
+	if err != nil || queued {
+		return err
+	}
+
+	return r.receive(sender, request, details)
+}
+
+// Echo accepts the broadcast request echoed by the sender party.
+func (r *ReshareParty) Echo(sender *rarimo.Party, request *types.MsgSubmitRequest) error {
+	return r.echo.Echo(sender, request)
+}
+
+// receive delivers the message to the old and/or new committee party according to the message type.
+func (r *ReshareParty) receive(sender *rarimo.Party, request *types.MsgSubmitRequest, details []byte) error {
+	r.log.Debugf("Received %s resharing request from %s", r.scheme.String(), sender.Account)
+
+	isBroadcast := request.Data.IsBroadcast
+	details, err := decrypt(r.secret, sender, isBroadcast, details)
+	if err != nil {
+		return err
+	}
+
+	fromOld, toOld, toNew, err := reshareRouting(details)
+	if err != nil {
+		return err
+	}
+
+	if isBroadcast && sender.Account != r.secret.AccountAddress() {
+		var receivers []*rarimo.Party
+		if toOld {
+			receivers = append(receivers, r.oldParties...)
+		}
+		if toNew {
+			receivers = append(receivers, r.newParties...)
+		}
+
+		if err := r.echo.received(sender, request, receivers); err != nil {
+			return err
+		}
+	}
+
+	from := r.newIds.FindByKey(core.GetTssShareKey(sender.Account, r.generation+1))
+	if fromOld {
+		from = r.oldIds.FindByKey(core.GetTssShareKey(sender.Account, r.generation))
+	}
+
+	if from == nil {
+		return ErrSenderIsNotCommitteeMember
+	}
+
+	if toOld && r.oldParty != nil {
+		if err := r.outbox.update(r.oldParty, details, from, isBroadcast); err != nil {
+			return err
+		}
+		logPartyStatus(r.log, r.oldParty, r.secret.AccountAddress())
+	}
+
+	if toNew {
+		if err := r.outbox.update(r.newParty, details, from, isBroadcast); err != nil {
+			return err
+		}
+		logPartyStatus(r.log, r.newParty, r.secret.AccountAddress())
+	}
+
+	r.log.Debugf("Finished processing %s resharing request from %s", r.scheme.String(), sender.Account)
+	return nil
+}
+
+func (r *ReshareParty) Run(ctx context.Context) {
+	r.log.Infof("Running TSS %s resharing from %v to %v", r.scheme.String(), r.oldIds, r.newIds)
+	oldCtx := tss.NewPeerContext(r.oldIds)
+	newCtx := tss.NewPeerContext(r.newIds)
+	newT := crypto.GetThreshold(r.newIds.Len())
+	oldSelf := r.oldIds.FindByKey(core.GetTssShareKey(r.secret.AccountAddress(), r.generation))
+	self := r.newIds.FindByKey(core.GetTssShareKey(r.secret.AccountAddress(), r.generation+1))
+
+	// Both parties should be initialized before start, because self messages are delivered to them immediately
+	var startNew, startOld func()
+	switch r.scheme {
+	case types.KeyScheme_EdDSA:
+		if oldSelf != nil {
+			oldEnd := make(chan *eddsakeygen.LocalPartySaveData, EndChannelSize)
+			params := tss.NewReSharingParameters(tss.Edwards(), oldCtx, newCtx, oldSelf, r.oldIds.Len(), r.oldT, r.newIds.Len(), newT)
+			r.oldParty = r.secret.GetEdDSAReshareParty(params, r.outbox.out, oldEnd)
+			startOld = func() {
+				go r.runOldEdDSA(ctx, oldEnd)
+				r.start(r.oldParty, func() { close(oldEnd) })
+			}
+		}
+
+		end := make(chan *eddsakeygen.LocalPartySaveData, EndChannelSize)
+		params := tss.NewReSharingParameters(tss.Edwards(), oldCtx, newCtx, self, r.oldIds.Len(), r.oldT, r.newIds.Len(), newT)
+		r.newParty = r.secret.GetNewCommitteeEdDSAReshareParty(params, r.outbox.out, end)
+		startNew = func() {
+			go r.runEdDSA(ctx, end)
+			r.start(r.newParty, func() { close(end) })
+		}
+	default:
+		if oldSelf != nil {
+			oldEnd := make(chan *keygen.LocalPartySaveData, EndChannelSize)
+			params := tss.NewReSharingParameters(tss.S256(), oldCtx, newCtx, oldSelf, r.oldIds.Len(), r.oldT, r.newIds.Len(), newT)
+			r.oldParty = r.secret.GetReshareParty(params, r.outbox.out, oldEnd)
+			startOld = func() {
+				go r.runOld(ctx, oldEnd)
+				r.start(r.oldParty, func() { close(oldEnd) })
+			}
+		}
+
+		end := make(chan *keygen.LocalPartySaveData, EndChannelSize)
+		params := tss.NewReSharingParameters(tss.S256(), oldCtx, newCtx, self, r.oldIds.Len(), r.oldT, r.newIds.Len(), newT)
+		r.newParty = r.secret.GetNewCommitteeReshareParty(params, r.outbox.out, end)
+		startNew = func() {
+			go r.run(ctx, end)
+			r.start(r.newParty, func() { close(end) })
+		}
+	}
+
+	r.wg.Add(3)
+	go r.echo.run(ctx, r.wg)
+	go r.listenOutput(ctx)
+	startNew()
+
+	if startOld != nil {
+		r.wg.Add(1)
+		startOld()
+	}
+
+	r.receiveWaiting()
+}
+
+func (r *ReshareParty) WaitFor() {
+	r.log.Debug("Waiting for finishing resharing party group")
+	r.wg.Wait()
+	r.log.Debug("Resharing party group finished")
+}
+
+func (r *ReshareParty) start(party tss.Party, closeEnd func()) {
+	go func() {
+		err := r.outbox.process(party.Start)
+		if err != nil {
+			r.log.WithError(err).Error("Error running tss party")
+			r.add(ErrorCulprits(err, r.secret.AccountAddress())...)
+			closeEnd()
+		}
+	}()
+}
+
+// receiveWaiting processes the messages received before the party initialization.
+func (r *ReshareParty) receiveWaiting() {
+	messages := r.waiting.open()
+	if len(messages) == 0 {
+		return
+	}
+
+	r.log.Debug("Processing waiting messages")
+
+	for _, msg := range messages {
+		if err := r.receive(msg.sender, msg.request, msg.details); err != nil {
+			r.log.WithError(err).Error("failed to receive waiting message")
+		}
+	}
+}
+
+// runOld waits for the old committee party to finish. Its result does not contain the key share.
+func (r *ReshareParty) runOld(ctx context.Context, end <-chan *keygen.LocalPartySaveData) {
+	defer func() {
+		r.log.Debug("Listening to old committee resharing party result finished")
+		r.wg.Done()
+	}()
+
+	select {
+	case _, ok := <-end:
+		if !ok {
+			r.log.Error("TSS old committee party chanel closed")
+			return
+		}
+	case <-ctx.Done():
+		select {
+		case _, ok := <-end:
+			if !ok {
+				r.log.Error("TSS old committee party chanel closed")
+				return
+			}
+		default:
+			r.log.Error("Old committee resharing process has not been finished yet or has some errors")
+			r.add(waitingCulprits(r.oldParty, r.secret.AccountAddress())...)
+			return
+		}
+	}
+
+	r.log.Info("Key share has been distributed to the new committee")
+	r.oldDone.Store(true)
+}
+
+// runOldEdDSA waits for the EdDSA old committee party to finish. Its result does not contain the key share.
+func (r *ReshareParty) runOldEdDSA(ctx context.Context, end <-chan *eddsakeygen.LocalPartySaveData) {
+	defer func() {
+		r.log.Debug("Listening to EdDSA old committee resharing party result finished")
+		r.wg.Done()
+	}()
+
+	select {
+	case _, ok := <-end:
+		if !ok {
+			r.log.Error("TSS old committee party chanel closed")
+			return
+		}
+	case <-ctx.Done():
+		select {
+		case _, ok := <-end:
+			if !ok {
+				r.log.Error("TSS old committee party chanel closed")
+				return
+			}
+		default:
+			r.log.Error("EdDSA old committee resharing process has not been finished yet or has some errors")
+			r.add(waitingCulprits(r.oldParty, r.secret.AccountAddress())...)
+			return
+		}
+	}
+
+	r.log.Info("EdDSA key share has been distributed to the new committee")
+	r.oldDone.Store(true)
+}
+
+func (r *ReshareParty) run(ctx context.Context, end <-chan *keygen.LocalPartySaveData) {
+	defer func() {
+		r.log.Debug("Listening to resharing party result finished")
+		r.wg.Done()
+	}()
+
+	var (
+		result *keygen.LocalPartySaveData
+		ok     bool
+	)
+
+	select {
+	case result, ok = <-end:
+	case <-ctx.Done():
+		select {
+		case result, ok = <-end:
+		default:
+			r.log.Error("Resharing process has not been finished yet or has some errors")
+			r.add(waitingCulprits(r.newParty, r.secret.AccountAddress())...)
+			return
+		}
+	}
+
+	if !ok {
+		r.log.Error("TSS party chanel closed")
+		return
+	}
+
+	r.log.Infof("Received new key share for public key: %s", hexutil.Encode(elliptic.Marshal(s256k1.S256(), result.ECDSAPub.X(), result.ECDSAPub.Y())))
+	r.result = result
+	r.done.Store(true)
+}
+
+func (r *ReshareParty) runEdDSA(ctx context.Context, end <-chan *eddsakeygen.LocalPartySaveData) {
+	defer func() {
+		r.log.Debug("Listening to EdDSA resharing party result finished")
+		r.wg.Done()
+	}()
+
+	var (
+		result *eddsakeygen.LocalPartySaveData
+		ok     bool
+	)
+
+	select {
+	case result, ok = <-end:
+	case <-ctx.Done():
+		select {
+		case result, ok = <-end:
+		default:
+			r.log.Error("EdDSA resharing process has not been finished yet or has some errors")
+			r.add(waitingCulprits(r.newParty, r.secret.AccountAddress())...)
+			return
+		}
+	}
+
+	if !ok {
+		r.log.Error("TSS party chanel closed")
+		return
+	}
+
+	r.log.Infof("Received new EdDSA key share for public key: %s", hexutil.Encode(edwards.NewPublicKey(result.EDDSAPub.X(), result.EDDSAPub.Y()).SerializeCompressed()))
+	r.eddsaResult = result
+	r.done.Store(true)
+}
+
+func (r *ReshareParty) listenOutput(ctx context.Context) {
+	defer func() {
+		r.log.Debug("Listening to resharing party output finished")
+		r.wg.Done()
+	}()
+
+	r.outbox.run(ctx, r.send)
+}
+
+// send submits the message to all receivers. Party that belongs to both committees receives the message once
+// and routes it to its tss parties by itself.
+func (r *ReshareParty) send(ctx context.Context, msg tss.Message) {
+	details, err := anypb.New(msg.WireMsg().Message)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to parse details")
+		return
+	}
+
+	build := func(details *anypb.Any) (*types.MsgSubmitRequest, error) {
+		return &types.MsgSubmitRequest{
+			Data: &types.RequestData{
+				Type:        types.RequestType_Keygen,
+				Id:          r.id,
+				IsBroadcast: msg.IsBroadcast(),
+				Details:     details,
+				Scheme:      r.scheme,
+			},
+		}, nil
+	}
+
+	receivers := make([]*rarimo.Party, 0, len(msg.GetTo()))
+	seen := make(map[string]struct{}, len(msg.GetTo()))
+
+	for _, receiver := range msg.GetTo() {
+		if _, ok := seen[receiver.Id]; ok {
+			continue
+		}
+		seen[receiver.Id] = struct{}{}
+
+		party := r.parties[receiver.Id]
+		if party.Account == r.secret.AccountAddress() {
+			r.log.Debugf("Sending to self (%s)", party.Account)
+			request, _ := build(details)
+			if err := r.Receive(party, request, details.Value); err != nil {
+				r.log.WithError(err).Error("error submitting request to self")
+			}
+			continue
+		}
+
+		receivers = append(receivers, party)
+	}
+
+	submit(ctx, r.con, r.core, r.secret, msg.IsBroadcast(), details, build, r.log, receivers...)
+}
+
+// reshareRouting returns the sender and receivers committees of the resharing message.
+func reshareRouting(details []byte) (fromOld, toOld, toNew bool, err error) {
+	wire := new(anypb.Any)
+	if err := proto.Unmarshal(details, wire); err != nil {
+		return false, false, false, err
+	}
+
+	content, err := wire.UnmarshalNew()
+	if err != nil {
+		return false, false, false, err
+	}
+
+	switch content.(type) {
+	case *resharing.DGRound1Message, *resharing.DGRound3Message1, *resharing.DGRound3Message2:
+		return true, false, true, nil
+	case *resharing.DGRound2Message1, *resharing.DGRound4Message1:
+		return false, false, true, nil
+	case *resharing.DGRound2Message2:
+		return false, true, false, nil
+	case *resharing.DGRound4Message2:
+		return false, true, true, nil
+	case *eddsaresharing.DGRound1Message, *eddsaresharing.DGRound3Message1, *eddsaresharing.DGRound3Message2:
+		return true, false, true, nil
+	case *eddsaresharing.DGRound2Message:
+		return false, true, false, nil
+	case *eddsaresharing.DGRound4Message:
+		return false, true, true, nil
+	}
+
+	return false, false, false, ErrUnknownReshareMessage
+}
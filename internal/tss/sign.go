@@ -4,22 +4,26 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"sync/atomic"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/tss"
-	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/rarimo/rarimo-core/x/rarimocore/crypto"
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/config"
 	"github.com/rarimo/tss-svc/internal/connectors"
 	"github.com/rarimo/tss-svc/internal/core"
 	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/internal/timer"
 	"github.com/rarimo/tss-svc/pkg/types"
 	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 type SignParty struct {
+	culpritSet
 	wg *sync.WaitGroup
 
 	log *logan.Entry
@@ -31,48 +35,81 @@ type SignParty struct {
 	party tss.Party
 	con   *connectors.BroadcastConnector
 	core  *connectors.CoreConnector
+	echo  *echoBroadcast
 
 	data   string
 	id     uint64
-	result *common.SignatureData
+	scheme types.KeyScheme
+	// generation of the key share used to derive party keys
+	generation uint64
+	result     *common.SignatureData
 
-	waiting chan waitingMessage
+	waiting *waitingQueue
+
+	outbox *outbox
+	done   atomic.Bool
 }
 
-func NewSignParty(data string, id uint64, sessionType types.SessionType, parties []*rarimo.Party, secret *secret.TssSecret, coreCon *connectors.CoreConnector, log *logan.Entry) *SignParty {
-	return &SignParty{
-		wg:       &sync.WaitGroup{},
-		log:      log,
-		parties:  partiesByAccountMapping(parties),
-		partyIds: core.PartyIds(parties),
-		secret:   secret,
-		con:      connectors.NewBroadcastConnector(sessionType, parties, secret, log),
-		core:     coreCon,
-		data:     data,
-		id:       id,
-		waiting:  make(chan waitingMessage, WaitingCap),
+func NewSignParty(data string, id uint64, sessionType types.SessionType, scheme types.KeyScheme, parties []*rarimo.Party, secret *secret.TssSecret, transport connectors.Transport, timer *timer.Timer, broadcast *config.BroadcastInfo, coreCon *connectors.CoreConnector, log *logan.Entry) *SignParty {
+	// ECDSA and EdDSA keys are reshared independently, so they have own share generations
+	generation := secret.Generation()
+	if scheme == types.KeyScheme_EdDSA {
+		generation = secret.EdDSAGeneration()
+	}
+
+	p := &SignParty{
+		wg:         &sync.WaitGroup{},
+		log:        log,
+		parties:    partiesByAccountMapping(parties),
+		partyIds:   core.ShareIds(parties, generation),
+		generation: generation,
+		secret:     secret,
+		con:        connectors.NewBroadcastConnector(sessionType, parties, secret, transport, timer, broadcast, log),
+		core:       coreCon,
+		data:       data,
+		id:         id,
+		scheme:     scheme,
 	}
+
+	p.echo = newEchoBroadcast(id, sessionType, types.RequestType_Sign, scheme, parties, p.signDetails, &p.culpritSet, secret, p.con, coreCon, log)
+	p.outbox = newOutbox()
+	p.waiting = newWaitingQueue(&p.culpritSet)
+	return p
 }
 
 func (p *SignParty) Run(ctx context.Context) {
-	p.log.Infof("Running TSS signing on set: %v", p.parties)
-	self := p.partyIds.FindByKey(core.GetTssPartyKey(p.secret.AccountAddress()))
-	out := make(chan tss.Message, OutChannelSize)
+	p.log.Infof("Running TSS %s signing on set: %v", p.scheme.String(), p.parties)
+	self := p.partyIds.FindByKey(core.GetTssShareKey(p.secret.AccountAddress(), p.generation))
 	end := make(chan *common.SignatureData, EndChannelSize)
 	peerCtx := tss.NewPeerContext(p.partyIds)
-	params := tss.NewParameters(tss.S256(), peerCtx, self, p.partyIds.Len(), crypto.GetThreshold(p.partyIds.Len()))
-	p.party = p.secret.GetSignParty(new(big.Int).SetBytes(hexutil.MustDecode(p.data)), params, out, end)
+	msg := new(big.Int).SetBytes(hexutil.MustDecode(p.data))
+
+	switch p.scheme {
+	case types.KeyScheme_EdDSA:
+		// EdDSA party signs the big-endian bytes of the message number, so the data is prefixed
+		// to keep its leading zero bytes (see EdDSAMessage).
+		msg = new(big.Int).SetBytes(EdDSAMessage(hexutil.MustDecode(p.data)))
+		params := tss.NewParameters(tss.Edwards(), peerCtx, self, p.partyIds.Len(), crypto.GetThreshold(p.partyIds.Len()))
+		p.party = p.secret.GetEdDSASignParty(msg, params, p.outbox.out, end)
+	default:
+		params := tss.NewParameters(tss.S256(), peerCtx, self, p.partyIds.Len(), crypto.GetThreshold(p.partyIds.Len()))
+		p.party = p.secret.GetSignParty(msg, params, p.outbox.out, end)
+	}
+
 	go func() {
-		err := p.party.Start()
+		err := p.outbox.process(p.party.Start)
 		if err != nil {
 			p.log.WithError(err).Error("Error running tss party")
+			p.add(ErrorCulprits(err, p.secret.AccountAddress())...)
 			close(end)
 		}
 	}()
 
-	p.wg.Add(2)
+	p.wg.Add(3)
+	go p.echo.run(ctx, p.wg)
 	go p.run(ctx, end)
-	go p.listenOutput(ctx, out)
+	go p.listenOutput(ctx)
+	p.receiveWaiting()
 }
 
 func (p *SignParty) WaitFor() {
@@ -81,63 +118,95 @@ func (p *SignParty) WaitFor() {
 	p.log.Debug("Sign party group finished")
 }
 
+// Result returns the produced signature. Returns nil if any party has equivocated.
 func (p *SignParty) Result() *common.SignatureData {
+	if p.echo.Equivocated() {
+		return nil
+	}
+
 	return p.result
 }
 
+// Done returns true if the signature has been produced and all outgoing messages and echoes have been sent and received.
+// Party is also done if any party has equivocated: signing is aborted in such case.
+func (p *SignParty) Done() bool {
+	return p.echo.Equivocated() || (p.done.Load() && p.outbox.idle() && p.echo.done())
+}
+
 func (p *SignParty) Data() string {
 	return p.data
 }
 
-func (p *SignParty) Receive(sender *rarimo.Party, isBroadcast bool, details []byte) error {
-	if p.party != nil {
-		p.receiveWaiting()
-		return p.receive(sender, isBroadcast, details)
+func (p *SignParty) Receive(sender *rarimo.Party, request *types.MsgSubmitRequest, details []byte) error {
+	queued, err := p.waiting.push(waitingMessage{sender: sender, request: request, details: details})
+	if err != nil || queued {
+		return err
 	}
 
-	p.pushToWaiting(sender, isBroadcast, details)
-	return nil
+	return p.receive(sender, request, details)
+}
+
+// Echo accepts the broadcast request echoed by the sender party.
+func (p *SignParty) Echo(sender *rarimo.Party, request *types.MsgSubmitRequest) error {
+	return p.echo.Echo(sender, request)
 }
 
-func (p *SignParty) receive(sender *rarimo.Party, isBroadcast bool, details []byte) error {
-	p.log.Debugf("Processing signing request from %s", sender.Account)
-	_, data, _ := bech32.DecodeAndConvert(sender.Account)
-	_, err := p.party.UpdateFromBytes(details, p.partyIds.FindByKey(new(big.Int).SetBytes(data)), isBroadcast)
+func (p *SignParty) receive(sender *rarimo.Party, request *types.MsgSubmitRequest, details []byte) error {
+	p.log.Debugf("Processing %s signing request from %s", p.scheme.String(), sender.Account)
+	isBroadcast := request.Data.IsBroadcast
+	details, err := decrypt(p.secret, sender, isBroadcast, details)
 	if err != nil {
 		return err
 	}
+
+	if isBroadcast && sender.Account != p.secret.AccountAddress() {
+		if err := p.echo.received(sender, request, partiesList(p.parties)); err != nil {
+			return err
+		}
+	}
+
+	// Party returns *tss.Error, so it is not assigned to the error variable to keep nil result nil
+	if err := p.outbox.update(p.party, details, p.partyIds.FindByKey(core.GetTssShareKey(sender.Account, p.generation)), isBroadcast); err != nil {
+		return err
+	}
 	logPartyStatus(p.log, p.party, p.secret.AccountAddress())
-	p.log.Debugf("Finished processing signing request from %s", sender.Account)
+	p.log.Debugf("Finished processing %s signing request from %s", p.scheme.String(), sender.Account)
 
 	return nil
 }
 
+// receiveWaiting processes the messages received before the party initialization.
 func (p *SignParty) receiveWaiting() {
-	if len(p.waiting) == 0 {
+	messages := p.waiting.open()
+	if len(messages) == 0 {
 		return
 	}
 
 	p.log.Debug("Processing waiting messages")
 
-	for {
-		select {
-		case msg := <-p.waiting:
-			if err := p.receive(msg.sender, msg.isBroadcast, msg.details); err != nil {
-				p.log.WithError(err).Error("failed to receive waiting message")
-			}
-		default:
-			return
+	for _, msg := range messages {
+		if err := p.receive(msg.sender, msg.request, msg.details); err != nil {
+			p.log.WithError(err).Error("failed to receive waiting message")
 		}
 	}
 }
 
-func (p *SignParty) pushToWaiting(sender *rarimo.Party, isBroadcast bool, details []byte) {
-	p.log.Debug("Message will be pushed to the waiting queue")
-	p.waiting <- waitingMessage{
-		sender:      sender,
-		isBroadcast: isBroadcast,
-		details:     details,
+// signDetails returns the tss message details of the sign request for the current party data.
+func (p *SignParty) signDetails(data *types.RequestData) ([]byte, error) {
+	sign := new(types.SignRequest)
+	if err := data.Details.UnmarshalTo(sign); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling sign request")
+	}
+
+	if sign.Details == nil {
+		return nil, ErrInvalidEchoRequest
 	}
+
+	if sign.Data != p.data {
+		return nil, ErrForeignRequest
+	}
+
+	return sign.Details.Value, nil
 }
 
 func (p *SignParty) run(ctx context.Context, end <-chan *common.SignatureData) {
@@ -146,83 +215,97 @@ func (p *SignParty) run(ctx context.Context, end <-chan *common.SignatureData) {
 		p.wg.Done()
 	}()
 
-	<-ctx.Done()
+	var (
+		result *common.SignatureData
+		ok     bool
+	)
 
 	select {
-	case result, ok := <-end:
-		if !ok {
-			p.log.Error("TSS party chanel closed")
+	case result, ok = <-end:
+	case <-ctx.Done():
+		select {
+		case result, ok = <-end:
+		default:
+			p.log.Error("Signature process has not been finished yet or has some errors")
+			p.add(waitingCulprits(p.party, p.secret.AccountAddress())...)
 			return
 		}
+	}
 
-		p.result = result
-		p.log.Infof("Signed data %s signature %s", p.data, hexutil.Encode(append(p.result.Signature, p.result.SignatureRecovery...)))
-	default:
-		p.log.Error("Signature process has not been finished yet or has some errors")
+	if !ok {
+		p.log.Error("TSS party chanel closed")
+		return
 	}
+
+	p.result = result
+	p.done.Store(true)
+	p.log.Infof("Signed data %s %s signature %s", p.data, p.scheme.String(), hexutil.Encode(append(p.result.Signature, p.result.SignatureRecovery...)))
 }
 
-func (p *SignParty) listenOutput(ctx context.Context, out <-chan tss.Message) {
+func (p *SignParty) listenOutput(ctx context.Context) {
 	defer func() {
 		p.log.Debug("Listening to sign party output finished")
 		p.wg.Done()
 	}()
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case msg := <-out:
-			details, err := anypb.New(msg.WireMsg().Message)
-			if err != nil {
-				p.log.WithError(err).Error("Failed to parse details")
-				continue
-			}
-
-			sign, err := anypb.New(&types.SignRequest{
-				Data:    p.data,
-				Details: details,
-			})
+	p.outbox.run(ctx, p.send)
+}
 
-			if err != nil {
-				p.log.WithError(err).Error("Failed to parse sign")
-				continue
-			}
+func (p *SignParty) send(ctx context.Context, msg tss.Message) {
+	details, err := anypb.New(msg.WireMsg().Message)
+	if err != nil {
+		p.log.WithError(err).Error("Failed to parse details")
+		return
+	}
 
-			request := &types.MsgSubmitRequest{
-				Data: &types.RequestData{
-					Type:        types.RequestType_Sign,
-					Id:          p.id,
-					IsBroadcast: msg.IsBroadcast(),
-					Details:     sign,
-				},
-			}
+	build := func(details *anypb.Any) (*types.MsgSubmitRequest, error) {
+		sign, err := anypb.New(&types.SignRequest{
+			Data:    p.data,
+			Details: details,
+		})
 
-			to := msg.GetTo()
-			if msg.IsBroadcast() {
-				to = p.partyIds
-			}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse sign")
+		}
 
-			receivers := make([]*rarimo.Party, 0, len(to))
+		return &types.MsgSubmitRequest{
+			Data: &types.RequestData{
+				Type:        types.RequestType_Sign,
+				Id:          p.id,
+				IsBroadcast: msg.IsBroadcast(),
+				Details:     sign,
+				Scheme:      p.scheme,
+			},
+		}, nil
+	}
 
-			for _, receiver := range to {
-				party, _ := p.parties[receiver.Id]
+	to := msg.GetTo()
+	if msg.IsBroadcast() {
+		to = p.partyIds
+	}
 
-				if party.Account == p.secret.AccountAddress() {
-					p.log.Debugf("Sending to self (%s)", party.Account)
-					if err := p.Receive(party, msg.IsBroadcast(), details.Value); err != nil {
-						p.log.WithError(err).Error("error submitting request to self")
-					}
+	receivers := make([]*rarimo.Party, 0, len(to))
 
-					continue
-				}
+	for _, receiver := range to {
+		party, _ := p.parties[receiver.Id]
 
-				receivers = append(receivers, party)
+		if party.Account == p.secret.AccountAddress() {
+			p.log.Debugf("Sending to self (%s)", party.Account)
+			request, err := build(details)
+			if err != nil {
+				p.log.WithError(err).Error("Failed to build request")
+				continue
 			}
 
-			if failed := p.con.SubmitToWithReport(ctx, p.core, request, receivers...); len(failed) != 0 {
-				p.con.SubmitToWithReport(ctx, p.core, request, failed...)
+			if err := p.Receive(party, request, details.Value); err != nil {
+				p.log.WithError(err).Error("error submitting request to self")
 			}
+
+			continue
 		}
+
+		receivers = append(receivers, party)
 	}
+
+	submit(ctx, p.con, p.core, p.secret, msg.IsBroadcast(), details, build, p.log, receivers...)
 }
@@ -1,17 +1,18 @@
 package tss
 
 import (
+	"context"
+
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/internal/connectors"
+	"github.com/rarimo/tss-svc/internal/secret"
+	"github.com/rarimo/tss-svc/pkg/types"
 	"gitlab.com/distributed_lab/logan/v3"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
-type waitingMessage struct {
-	sender      *rarimo.Party
-	isBroadcast bool
-	details     []byte
-}
-
 func partiesByAccountMapping(parties []*rarimo.Party) map[string]*rarimo.Party {
 	pmap := make(map[string]*rarimo.Party)
 	for _, p := range parties {
@@ -20,6 +21,23 @@ func partiesByAccountMapping(parties []*rarimo.Party) map[string]*rarimo.Party {
 	return pmap
 }
 
+func partiesList(parties map[string]*rarimo.Party) []*rarimo.Party {
+	list := make([]*rarimo.Party, 0, len(parties))
+	for _, party := range parties {
+		list = append(list, party)
+	}
+	return list
+}
+
+// requestDetails returns the tss message details of the keygen and resharing requests.
+func requestDetails(data *types.RequestData) ([]byte, error) {
+	if data.Details == nil {
+		return nil, ErrInvalidEchoRequest
+	}
+
+	return data.Details.Value, nil
+}
+
 func logPartyStatus(log *logan.Entry, party tss.Party, self string) {
 	list := party.WaitingFor()
 	monikers := make([]string, 0, len(list))
@@ -34,3 +52,67 @@ func logPartyStatus(log *logan.Entry, party tss.Party, self string) {
 	log.Infof("Waiting for messages from: %v", monikers)
 	log.Infof("Party status: %s", party.String())
 }
+
+// requestBuilder wraps the tss message details into the request to be submitted.
+type requestBuilder func(details *anypb.Any) (*types.MsgSubmitRequest, error)
+
+// submit sends the tss message details to the receivers. Broadcast messages are shared as is.
+// Unicast messages are sent to every receiver separately with details encrypted for that receiver (see secret.TssSecret.Encrypt),
+// so their content stays confidential regardless of the transport between parties.
+func submit(
+	ctx context.Context,
+	con *connectors.BroadcastConnector,
+	coreCon *connectors.CoreConnector,
+	sc *secret.TssSecret,
+	isBroadcast bool,
+	details *anypb.Any,
+	build requestBuilder,
+	log *logan.Entry,
+	receivers ...*rarimo.Party,
+) {
+	if isBroadcast {
+		request, err := build(details)
+		if err != nil {
+			log.WithError(err).Error("Failed to build request")
+			return
+		}
+
+		if failed := con.SubmitToWithReport(ctx, coreCon, request, receivers...); len(failed) != 0 {
+			con.SubmitToWithReport(ctx, coreCon, request, failed...)
+		}
+		return
+	}
+
+	for _, receiver := range receivers {
+		encrypted, err := sc.Encrypt(receiver.PubKey, details.Value)
+		if err != nil {
+			log.WithError(err).Errorf("Failed to encrypt request for party %s", receiver.Account)
+			continue
+		}
+
+		request, err := build(&anypb.Any{TypeUrl: details.TypeUrl, Value: encrypted})
+		if err != nil {
+			log.WithError(err).Error("Failed to build request")
+			return
+		}
+
+		if failed := con.SubmitToWithReport(ctx, coreCon, request, receiver); len(failed) != 0 {
+			con.SubmitToWithReport(ctx, coreCon, request, failed...)
+		}
+	}
+}
+
+// decrypt returns the decrypted details of the unicast tss message. Broadcast messages and messages sent to self
+// are not encrypted and returned as is.
+func decrypt(sc *secret.TssSecret, sender *rarimo.Party, isBroadcast bool, details []byte) ([]byte, error) {
+	if isBroadcast || sender.Account == sc.AccountAddress() {
+		return details, nil
+	}
+
+	decrypted, err := sc.Decrypt(sender.PubKey, details)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt unicast message", logan.F{"sender": sender.Account})
+	}
+
+	return decrypted, nil
+}
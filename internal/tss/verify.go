@@ -0,0 +1,106 @@
+package tss
+
+import (
+	"bytes"
+	goerr "errors"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/crypto"
+	"gitlab.com/distributed_lab/logan/v3/errors"
+)
+
+var (
+	ErrInvalidSignatureLength = goerr.New("invalid signature length")
+	ErrInvalidRecoveryId      = goerr.New("invalid signature recovery id")
+	ErrSignatureKeyMismatch   = goerr.New("signature does not correspond to the public key")
+	ErrInvalidSignature       = goerr.New("signature verification failed")
+)
+
+// VerifyECDSA checks that the hex signature (r || s || v) of the hex hash has been produced by the provided
+// hex public key (uncompressed point without 0x04 prefix). Only 0 and 1 recovery ids and low S values are accepted.
+func VerifyECDSA(hash, signature, pubKey string) error {
+	hashBytes, err := hexutil.Decode(hash)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode hash")
+	}
+
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signature")
+	}
+
+	key, err := hexutil.Decode(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode public key")
+	}
+
+	if len(sig) != eth.SignatureLength {
+		return ErrInvalidSignatureLength
+	}
+
+	if sig[eth.RecoveryIDOffset] > 1 {
+		return ErrInvalidRecoveryId
+	}
+
+	recovered, err := eth.Ecrecover(hashBytes, sig)
+	if err != nil {
+		return errors.Wrap(err, "failed to recover public key")
+	}
+
+	// Recovered key contains constant 0x04 first byte
+	if !bytes.Equal(recovered[1:], key) {
+		return ErrSignatureKeyMismatch
+	}
+
+	if !eth.VerifySignature(recovered, hashBytes, sig[:eth.RecoveryIDOffset]) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// EdDSAMessagePrefix is prepended to the data signed with EdDSA key. The tss party signs the big-endian bytes
+// of the message number, so the non-zero prefix keeps the leading zero bytes of data and every data is signed
+// with the fixed-length encoding.
+const EdDSAMessagePrefix byte = 0x01
+
+// EdDSAMessage returns the bytes signed by the EdDSA tss party for the provided data bytes (see EdDSAMessagePrefix).
+func EdDSAMessage(data []byte) []byte {
+	return append([]byte{EdDSAMessagePrefix}, data...)
+}
+
+// VerifyEdDSA checks that the hex Ed25519 signature of the hex data encoded with EdDSAMessage has been produced
+// by the provided hex compressed public key.
+func VerifyEdDSA(data, signature, pubKey string) error {
+	dataBytes, err := hexutil.Decode(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode data")
+	}
+
+	sigBytes, err := hexutil.Decode(signature)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signature")
+	}
+
+	keyBytes, err := hexutil.Decode(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode public key")
+	}
+
+	sig, err := edwards.ParseSignature(sigBytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse signature")
+	}
+
+	key, err := edwards.ParsePubKey(keyBytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse public key")
+	}
+
+	if !edwards.Verify(key, EdDSAMessage(dataBytes), sig.R, sig.S) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
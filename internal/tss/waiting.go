@@ -0,0 +1,80 @@
+package tss
+
+import (
+	goerr "errors"
+	"sync"
+
+	rarimo "github.com/rarimo/rarimo-core/x/rarimocore/types"
+	"github.com/rarimo/tss-svc/pkg/types"
+)
+
+// ErrWaitingQueueFull is returned if the sender has exceeded the amount of messages buffered before the party start
+var ErrWaitingQueueFull = goerr.New("waiting queue is full for the sender")
+
+type waitingMessage struct {
+	sender  *rarimo.Party
+	request *types.MsgSubmitRequest
+	details []byte
+}
+
+// waitingQueue buffers the messages received before the tss party has been initialized.
+// Every sender has its own bounded quota, so the flooding party can not displace the messages of other parties.
+// Pushing never blocks: messages over the quota are dropped and the sender is blamed for spam once.
+type waitingQueue struct {
+	mu       sync.Mutex
+	opened   bool
+	messages []waitingMessage
+	counts   map[string]int
+	blamed   map[string]struct{}
+	culprits *culpritSet
+}
+
+func newWaitingQueue(culprits *culpritSet) *waitingQueue {
+	return &waitingQueue{
+		messages: make([]waitingMessage, 0),
+		counts:   make(map[string]int),
+		blamed:   make(map[string]struct{}),
+		culprits: culprits,
+	}
+}
+
+// push buffers the message if the queue has not been opened yet. Returns false if the message should be processed
+// immediately by the caller.
+func (q *waitingQueue) push(msg waitingMessage) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.opened {
+		return false, nil
+	}
+
+	account := msg.sender.Account
+	if q.counts[account] >= WaitingCap {
+		if _, ok := q.blamed[account]; !ok {
+			q.blamed[account] = struct{}{}
+			q.culprits.add(Culprit{
+				Account: account,
+				Type:    rarimo.ViolationType_Spam,
+				Message: "Party has sent too many tss messages before the protocol start",
+			})
+		}
+
+		return false, ErrWaitingQueueFull
+	}
+
+	q.counts[account]++
+	q.messages = append(q.messages, msg)
+	return true, nil
+}
+
+// open returns all buffered messages in order of receiving. All next messages should be processed immediately.
+func (q *waitingQueue) open() []waitingMessage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.opened = true
+	messages := q.messages
+	q.messages = nil
+	q.counts = nil
+	return messages
+}
@@ -24,6 +24,7 @@ const _ = grpc.SupportPackageIsVersion7
 type ServiceClient interface {
 	Submit(ctx context.Context, in *MsgSubmitRequest, opts ...grpc.CallOption) (*MsgSubmitResponse, error)
 	AddOperation(ctx context.Context, in *MsgAddOperationRequest, opts ...grpc.CallOption) (*MsgAddOperationResponse, error)
+	Stream(ctx context.Context, opts ...grpc.CallOption) (Service_StreamClient, error)
 	Info(ctx context.Context, in *MsgInfoRequest, opts ...grpc.CallOption) (*MsgInfoResponse, error)
 	Session(ctx context.Context, in *MsgSessionRequest, opts ...grpc.CallOption) (*MsgSessionResponse, error)
 }
@@ -54,6 +55,37 @@ func (c *serviceClient) AddOperation(ctx context.Context, in *MsgAddOperationReq
 	return out, nil
 }
 
+func (c *serviceClient) Stream(ctx context.Context, opts ...grpc.CallOption) (Service_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[0], "/Service/Stream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceStreamClient{stream}
+	return x, nil
+}
+
+type Service_StreamClient interface {
+	Send(*StreamFrame) error
+	Recv() (*StreamAck, error)
+	grpc.ClientStream
+}
+
+type serviceStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceStreamClient) Send(m *StreamFrame) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serviceStreamClient) Recv() (*StreamAck, error) {
+	m := new(StreamAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *serviceClient) Info(ctx context.Context, in *MsgInfoRequest, opts ...grpc.CallOption) (*MsgInfoResponse, error) {
 	out := new(MsgInfoResponse)
 	err := c.cc.Invoke(ctx, "/Service/Info", in, out, opts...)
@@ -78,6 +110,7 @@ func (c *serviceClient) Session(ctx context.Context, in *MsgSessionRequest, opts
 type ServiceServer interface {
 	Submit(context.Context, *MsgSubmitRequest) (*MsgSubmitResponse, error)
 	AddOperation(context.Context, *MsgAddOperationRequest) (*MsgAddOperationResponse, error)
+	Stream(Service_StreamServer) error
 	Info(context.Context, *MsgInfoRequest) (*MsgInfoResponse, error)
 	Session(context.Context, *MsgSessionRequest) (*MsgSessionResponse, error)
 }
@@ -92,6 +125,9 @@ func (UnimplementedServiceServer) Submit(context.Context, *MsgSubmitRequest) (*M
 func (UnimplementedServiceServer) AddOperation(context.Context, *MsgAddOperationRequest) (*MsgAddOperationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddOperation not implemented")
 }
+func (UnimplementedServiceServer) Stream(Service_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
 func (UnimplementedServiceServer) Info(context.Context, *MsgInfoRequest) (*MsgInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
 }
@@ -146,6 +182,32 @@ func _Service_AddOperation_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceServer).Stream(&serviceStreamServer{stream})
+}
+
+type Service_StreamServer interface {
+	Send(*StreamAck) error
+	Recv() (*StreamFrame, error)
+	grpc.ServerStream
+}
+
+type serviceStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceStreamServer) Send(m *StreamAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serviceStreamServer) Recv() (*StreamFrame, error) {
+	m := new(StreamFrame)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Service_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MsgInfoRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +268,13 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Service_Session_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _Service_Stream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "service.proto",
 }
@@ -70,6 +70,52 @@ func (SessionType) EnumDescriptor() ([]byte, []int) {
 	return file_session_proto_rawDescGZIP(), []int{0}
 }
 
+type KeyScheme int32
+
+const (
+	KeyScheme_ECDSA KeyScheme = 0
+	KeyScheme_EdDSA KeyScheme = 1
+)
+
+// Enum value maps for KeyScheme.
+var (
+	KeyScheme_name = map[int32]string{
+		0: "ECDSA",
+		1: "EdDSA",
+	}
+	KeyScheme_value = map[string]int32{
+		"ECDSA": 0,
+		"EdDSA": 1,
+	}
+)
+
+func (x KeyScheme) Enum() *KeyScheme {
+	p := new(KeyScheme)
+	*p = x
+	return p
+}
+
+func (x KeyScheme) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyScheme) Descriptor() protoreflect.EnumDescriptor {
+	return file_session_proto_enumTypes[1].Descriptor()
+}
+
+func (KeyScheme) Type() protoreflect.EnumType {
+	return &file_session_proto_enumTypes[1]
+}
+
+func (x KeyScheme) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyScheme.Descriptor instead.
+func (KeyScheme) EnumDescriptor() ([]byte, []int) {
+	return file_session_proto_rawDescGZIP(), []int{1}
+}
+
 type SessionStatus int32
 
 const (
@@ -103,11 +149,11 @@ func (x SessionStatus) String() string {
 }
 
 func (SessionStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_session_proto_enumTypes[1].Descriptor()
+	return file_session_proto_enumTypes[2].Descriptor()
 }
 
 func (SessionStatus) Type() protoreflect.EnumType {
-	return &file_session_proto_enumTypes[1]
+	return &file_session_proto_enumTypes[2]
 }
 
 func (x SessionStatus) Number() protoreflect.EnumNumber {
@@ -116,7 +162,7 @@ func (x SessionStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SessionStatus.Descriptor instead.
 func (SessionStatus) EnumDescriptor() ([]byte, []int) {
-	return file_session_proto_rawDescGZIP(), []int{1}
+	return file_session_proto_rawDescGZIP(), []int{2}
 }
 
 type Session struct {
@@ -211,12 +257,15 @@ type DefaultSessionData struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Parties   []string `protobuf:"bytes,1,rep,name=parties,proto3" json:"parties,omitempty"`
-	Proposer  string   `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
-	Indexes   []string `protobuf:"bytes,3,rep,name=indexes,proto3" json:"indexes,omitempty"`
-	Root      string   `protobuf:"bytes,4,opt,name=root,proto3" json:"root,omitempty"`
-	Accepted  []string `protobuf:"bytes,5,rep,name=accepted,proto3" json:"accepted,omitempty"`
-	Signature string   `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Parties        []string `protobuf:"bytes,1,rep,name=parties,proto3" json:"parties,omitempty"`
+	Proposer       string   `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Indexes        []string `protobuf:"bytes,3,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	Root           string   `protobuf:"bytes,4,opt,name=root,proto3" json:"root,omitempty"`
+	Accepted       []string `protobuf:"bytes,5,rep,name=accepted,proto3" json:"accepted,omitempty"`
+	Signature      string   `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	EddsaSignature string   `protobuf:"bytes,7,opt,name=eddsaSignature,proto3" json:"eddsaSignature,omitempty"`
+	// signing round and signature that failed verification
+	SignatureFailure string `protobuf:"bytes,8,opt,name=signatureFailure,proto3" json:"signatureFailure,omitempty"`
 }
 
 func (x *DefaultSessionData) Reset() {
@@ -293,18 +342,36 @@ func (x *DefaultSessionData) GetSignature() string {
 	return ""
 }
 
+func (x *DefaultSessionData) GetEddsaSignature() string {
+	if x != nil {
+		return x.EddsaSignature
+	}
+	return ""
+}
+
+func (x *DefaultSessionData) GetSignatureFailure() string {
+	if x != nil {
+		return x.SignatureFailure
+	}
+	return ""
+}
+
 type ReshareSessionData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Parties      []string `protobuf:"bytes,1,rep,name=parties,proto3" json:"parties,omitempty"`
-	Proposer     string   `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
-	OldKey       string   `protobuf:"bytes,3,opt,name=oldKey,proto3" json:"oldKey,omitempty"`
-	NewKey       string   `protobuf:"bytes,4,opt,name=newKey,proto3" json:"newKey,omitempty"`
-	KeySignature string   `protobuf:"bytes,5,opt,name=keySignature,proto3" json:"keySignature,omitempty"`
-	Signature    string   `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
-	Root         string   `protobuf:"bytes,7,opt,name=root,proto3" json:"root,omitempty"`
+	Parties           []string `protobuf:"bytes,1,rep,name=parties,proto3" json:"parties,omitempty"`
+	Proposer          string   `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	OldKey            string   `protobuf:"bytes,3,opt,name=oldKey,proto3" json:"oldKey,omitempty"`
+	NewKey            string   `protobuf:"bytes,4,opt,name=newKey,proto3" json:"newKey,omitempty"`
+	KeySignature      string   `protobuf:"bytes,5,opt,name=keySignature,proto3" json:"keySignature,omitempty"`
+	Signature         string   `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Root              string   `protobuf:"bytes,7,opt,name=root,proto3" json:"root,omitempty"`
+	NewEdDSAKey       string   `protobuf:"bytes,8,opt,name=newEdDSAKey,proto3" json:"newEdDSAKey,omitempty"`
+	EddsaKeySignature string   `protobuf:"bytes,9,opt,name=eddsaKeySignature,proto3" json:"eddsaKeySignature,omitempty"`
+	// signing round and signature that failed verification
+	SignatureFailure string `protobuf:"bytes,10,opt,name=signatureFailure,proto3" json:"signatureFailure,omitempty"`
 }
 
 func (x *ReshareSessionData) Reset() {
@@ -388,13 +455,37 @@ func (x *ReshareSessionData) GetRoot() string {
 	return ""
 }
 
+func (x *ReshareSessionData) GetNewEdDSAKey() string {
+	if x != nil {
+		return x.NewEdDSAKey
+	}
+	return ""
+}
+
+func (x *ReshareSessionData) GetEddsaKeySignature() string {
+	if x != nil {
+		return x.EddsaKeySignature
+	}
+	return ""
+}
+
+func (x *ReshareSessionData) GetSignatureFailure() string {
+	if x != nil {
+		return x.SignatureFailure
+	}
+	return ""
+}
+
 type KeygenSessionData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Parties []string `protobuf:"bytes,1,rep,name=parties,proto3" json:"parties,omitempty"`
-	Key     string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Parties  []string `protobuf:"bytes,1,rep,name=parties,proto3" json:"parties,omitempty"`
+	Key      string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	EddsaKey string   `protobuf:"bytes,3,opt,name=eddsaKey,proto3" json:"eddsaKey,omitempty"`
+	// attempt is the number of key generation attempt starting from 1
+	Attempt uint64 `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
 }
 
 func (x *KeygenSessionData) Reset() {
@@ -443,6 +534,20 @@ func (x *KeygenSessionData) GetKey() string {
 	return ""
 }
 
+func (x *KeygenSessionData) GetEddsaKey() string {
+	if x != nil {
+		return x.EddsaKey
+	}
+	return ""
+}
+
+func (x *KeygenSessionData) GetAttempt() uint64 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
 var File_session_proto protoreflect.FileDescriptor
 
 var file_session_proto_rawDesc = []byte{
@@ -461,7 +566,7 @@ var file_session_proto_rawDesc = []byte{
 	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x04,
 	0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xb2, 0x01, 0x0a, 0x12, 0x44, 0x65, 0x66, 0x61, 0x75,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x86, 0x02, 0x0a, 0x12, 0x44, 0x65, 0x66, 0x61, 0x75,
 	0x6c, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a,
 	0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
 	0x70, 0x61, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f,
@@ -472,37 +577,56 @@ var file_session_proto_rawDesc = []byte{
 	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
 	0x03, 0x28, 0x09, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a,
 	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xd0, 0x01, 0x0a, 0x12,
-	0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x61,
-	0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
-	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x6c, 0x64, 0x4b,
-	0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x6c, 0x64, 0x4b, 0x65, 0x79,
-	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x77, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x6e, 0x65, 0x77, 0x4b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x6b, 0x65, 0x79, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x6b, 0x65, 0x79, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f,
-	0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x22, 0x3f,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x65,
+	0x64, 0x64, 0x73, 0x61, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x64, 0x64, 0x73, 0x61, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22,
+	0xcc, 0x02, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x6c, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x6c,
+	0x64, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x77, 0x4b, 0x65, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x77, 0x4b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x0c,
+	0x6b, 0x65, 0x79, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f,
+	0x6f, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x45, 0x64, 0x44, 0x53, 0x41, 0x4b, 0x65,
+	0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x45, 0x64, 0x44, 0x53,
+	0x41, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x64, 0x64, 0x73, 0x61, 0x4b, 0x65, 0x79,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x65, 0x64, 0x64, 0x73, 0x61, 0x4b, 0x65, 0x79, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0x75,
 	0x0a, 0x11, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44,
 	0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01,
 	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x2a,
-	0x48, 0x0a, 0x0b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12,
-	0x0a, 0x0e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x68, 0x61, 0x72, 0x65, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e,
-	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x02, 0x2a, 0x4f, 0x0a, 0x0d, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x10,
-	0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c,
-	0x65, 0x64, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53,
-	0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x03, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69,
-	0x74, 0x6c, 0x61, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61, 0x72, 0x69, 0x6d, 0x6f, 0x2f,
-	0x74, 0x73, 0x73, 0x2f, 0x74, 0x73, 0x73, 0x2d, 0x73, 0x76, 0x63, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x65, 0x64, 0x64, 0x73, 0x61, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x65, 0x64, 0x64, 0x73, 0x61, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x2a, 0x48, 0x0a, 0x0b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
+	0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x02, 0x2a,
+	0x21, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x09, 0x0a, 0x05,
+	0x45, 0x43, 0x44, 0x53, 0x41, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x64, 0x44, 0x53, 0x41,
+	0x10, 0x01, 0x2a, 0x4f, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x01, 0x12, 0x14, 0x0a,
+	0x10, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65,
+	0x64, 0x10, 0x03, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x72, 0x61, 0x72, 0x69, 0x6d, 0x6f, 0x2f, 0x74, 0x73, 0x73, 0x2d, 0x73, 0x76, 0x63,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -517,21 +641,22 @@ func file_session_proto_rawDescGZIP() []byte {
 	return file_session_proto_rawDescData
 }
 
-var file_session_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_session_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
 var file_session_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_session_proto_goTypes = []interface{}{
 	(SessionType)(0),           // 0: SessionType
-	(SessionStatus)(0),         // 1: SessionStatus
-	(*Session)(nil),            // 2: Session
-	(*DefaultSessionData)(nil), // 3: DefaultSessionData
-	(*ReshareSessionData)(nil), // 4: ReshareSessionData
-	(*KeygenSessionData)(nil),  // 5: KeygenSessionData
-	(*anypb.Any)(nil),          // 6: google.protobuf.Any
+	(KeyScheme)(0),             // 1: KeyScheme
+	(SessionStatus)(0),         // 2: SessionStatus
+	(*Session)(nil),            // 3: Session
+	(*DefaultSessionData)(nil), // 4: DefaultSessionData
+	(*ReshareSessionData)(nil), // 5: ReshareSessionData
+	(*KeygenSessionData)(nil),  // 6: KeygenSessionData
+	(*anypb.Any)(nil),          // 7: google.protobuf.Any
 }
 var file_session_proto_depIdxs = []int32{
-	1, // 0: Session.status:type_name -> SessionStatus
+	2, // 0: Session.status:type_name -> SessionStatus
 	0, // 1: Session.type:type_name -> SessionType
-	6, // 2: Session.data:type_name -> google.protobuf.Any
+	7, // 2: Session.data:type_name -> google.protobuf.Any
 	3, // [3:3] is the sub-list for method output_type
 	3, // [3:3] is the sub-list for method input_type
 	3, // [3:3] is the sub-list for extension type_name
@@ -599,7 +724,7 @@ func file_session_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_session_proto_rawDesc,
-			NumEnums:      2,
+			NumEnums:      3,
 			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,